@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MetricSpec associates a metric name with a short display alias, as
+// an alternative to showing the raw Prometheus metric name in the
+// dashboard. Endpoint, if set, scopes the alias to samples scraped
+// from that one endpoint; left empty, the alias applies regardless of
+// which endpoint reported the metric.
+type MetricSpec struct {
+	Name     string
+	Alias    string
+	Endpoint string
+}
+
+// parseAliasSpecs parses --alias flag values of the form "name=alias"
+// or "endpoint|name=alias" into MetricSpecs.
+func parseAliasSpecs(raw []string) ([]MetricSpec, error) {
+	specs := make([]MetricSpec, 0, len(raw))
+	for _, r := range raw {
+		endpoint, rest := "", r
+		if idx := strings.Index(r, "|"); idx != -1 {
+			endpoint, rest = r[:idx], r[idx+1:]
+		}
+		name, alias, ok := strings.Cut(rest, "=")
+		if !ok || name == "" || alias == "" {
+			return nil, fmt.Errorf("invalid --alias %q: expected name=alias or endpoint|name=alias", r)
+		}
+		specs = append(specs, MetricSpec{Name: name, Alias: alias, Endpoint: endpoint})
+	}
+	return specs, nil
+}
+
+// resolveAlias looks up the display alias for a metric name, scoped
+// to the given endpoint. An endpoint-scoped spec takes precedence
+// over a global one (Endpoint == ""); among specs of equal
+// precedence, the last one given wins, matching how repeated cobra
+// flags are otherwise applied. ok is false when no spec matches,
+// telling the caller to fall back to the raw metric name.
+func resolveAlias(specs []MetricSpec, endpoint, name string) (alias string, ok bool) {
+	const (
+		noMatch = iota
+		globalMatch
+		endpointMatch
+	)
+	matched := noMatch
+	for _, s := range specs {
+		if s.Name != name {
+			continue
+		}
+		precedence := globalMatch
+		if s.Endpoint != "" {
+			if s.Endpoint != endpoint {
+				continue
+			}
+			precedence = endpointMatch
+		}
+		if precedence >= matched {
+			alias, matched = s.Alias, precedence
+		}
+	}
+	return alias, matched != noMatch
+}
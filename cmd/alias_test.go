@@ -0,0 +1,70 @@
+package cmd
+
+import "testing"
+
+func TestParseAliasSpecs_NameEqualsAlias(t *testing.T) {
+	specs, err := parseAliasSpecs([]string{"cpu_usage=API CPU"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(specs) != 1 {
+		t.Fatalf("expected 1 spec, got %d", len(specs))
+	}
+	want := MetricSpec{Name: "cpu_usage", Alias: "API CPU"}
+	if specs[0] != want {
+		t.Errorf("expected %+v, got %+v", want, specs[0])
+	}
+}
+
+func TestParseAliasSpecs_EndpointScoped(t *testing.T) {
+	specs, err := parseAliasSpecs([]string{"http://host-a:9090/metrics|cpu_usage=Host A CPU"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := MetricSpec{Name: "cpu_usage", Alias: "Host A CPU", Endpoint: "http://host-a:9090/metrics"}
+	if specs[0] != want {
+		t.Errorf("expected %+v, got %+v", want, specs[0])
+	}
+}
+
+func TestParseAliasSpecs_RejectsMalformed(t *testing.T) {
+	for _, bad := range []string{"cpu_usage", "=alias", "cpu_usage="} {
+		if _, err := parseAliasSpecs([]string{bad}); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+}
+
+func TestResolveAlias_FallsBackToRawName(t *testing.T) {
+	specs := []MetricSpec{{Name: "cpu_usage", Alias: "API CPU"}}
+	if _, ok := resolveAlias(specs, "", "memory_bytes"); ok {
+		t.Error("expected no alias for an unconfigured metric")
+	}
+}
+
+func TestResolveAlias_EndpointScopedOverridesGlobal(t *testing.T) {
+	specs := []MetricSpec{
+		{Name: "cpu_usage", Alias: "CPU"},
+		{Name: "cpu_usage", Alias: "Host A CPU", Endpoint: "http://host-a"},
+	}
+	alias, ok := resolveAlias(specs, "http://host-a", "cpu_usage")
+	if !ok || alias != "Host A CPU" {
+		t.Errorf("expected endpoint-scoped alias to win, got %q (ok=%v)", alias, ok)
+	}
+
+	alias, ok = resolveAlias(specs, "http://host-b", "cpu_usage")
+	if !ok || alias != "CPU" {
+		t.Errorf("expected global alias for an unscoped endpoint, got %q (ok=%v)", alias, ok)
+	}
+}
+
+func TestResolveAlias_DuplicateCollisionLastOneWins(t *testing.T) {
+	specs := []MetricSpec{
+		{Name: "cpu_usage", Alias: "CPU"},
+		{Name: "cpu_usage", Alias: "Processor"},
+	}
+	alias, ok := resolveAlias(specs, "", "cpu_usage")
+	if !ok || alias != "Processor" {
+		t.Errorf("expected the later duplicate alias to win, got %q (ok=%v)", alias, ok)
+	}
+}
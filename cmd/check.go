@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mcpherrinm/hrmm/internal/fetcher"
+	"github.com/spf13/cobra"
+)
+
+var checkOutputFormat string
+
+// checkResult is one endpoint's probe outcome, in both the human table
+// and the -o json output.
+type checkResult struct {
+	URL       string  `json:"url"`
+	Status    string  `json:"status"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+
+	latency time.Duration // used for the human table's rounded display
+}
+
+var checkCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Probe configured endpoints, like promtool's healthy/ready checks",
+	Long:  "Hit a Prometheus-style probe endpoint (/-/healthy or /-/ready) on each configured --url and exit non-zero if any fail, so hrmm can drop into readiness gates and healthcheck cron jobs.",
+}
+
+var checkHealthyCmd = &cobra.Command{
+	Use:   "healthy",
+	Short: "Probe /-/healthy on each configured URL",
+	Run: func(cmd *cobra.Command, args []string) {
+		runCheck("/-/healthy")
+	},
+}
+
+var checkReadyCmd = &cobra.Command{
+	Use:   "ready",
+	Short: "Probe /-/ready on each configured URL",
+	Run: func(cmd *cobra.Command, args []string) {
+		runCheck("/-/ready")
+	},
+}
+
+func init() {
+	checkCmd.PersistentFlags().StringVarP(&checkOutputFormat, "output", "o", "", `output format, "json" for scripting (default: human-readable table)`)
+	checkCmd.AddCommand(checkHealthyCmd, checkReadyCmd)
+	rootCmd.AddCommand(checkCmd)
+}
+
+// runCheck probes path on every configured --url, reusing the same
+// --http.config.file credentials as graph, prints the results, and
+// exits non-zero if any endpoint failed.
+func runCheck(path string) {
+	var httpConfig *fetcher.HTTPClientConfig
+	if httpConfigFile != "" {
+		var err error
+		httpConfig, err = fetcher.LoadHTTPClientConfig(httpConfigFile)
+		if err != nil {
+			fmt.Printf("Error loading --http.config.file: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	results := make([]checkResult, len(urls))
+	allHealthy := true
+	for i, target := range urls {
+		client := http.DefaultClient
+		if httpConfig != nil {
+			var err error
+			client, err = httpConfig.ClientFor(target)
+			if err != nil {
+				results[i] = checkResult{URL: target, Status: "error", Error: err.Error()}
+				allHealthy = false
+				continue
+			}
+		}
+
+		start := time.Now()
+		resp, err := client.Get(target + path)
+		latency := time.Since(start)
+		if err != nil {
+			results[i] = checkResult{URL: target, Status: "error", LatencyMS: ms(latency), latency: latency, Error: err.Error()}
+			allHealthy = false
+			continue
+		}
+		resp.Body.Close()
+
+		status := "ok"
+		if resp.StatusCode != http.StatusOK {
+			status = "failed"
+			allHealthy = false
+		}
+		results[i] = checkResult{URL: target, Status: status, LatencyMS: ms(latency), latency: latency}
+		if resp.StatusCode != http.StatusOK {
+			results[i].Error = fmt.Sprintf("unexpected status %s", resp.Status)
+		}
+	}
+
+	if checkOutputFormat == "json" {
+		printCheckResultsJSON(results)
+	} else {
+		printCheckResultsTable(results)
+	}
+
+	if !allHealthy {
+		os.Exit(1)
+	}
+}
+
+// ms converts a duration to fractional milliseconds for JSON output.
+func ms(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func printCheckResultsTable(results []checkResult) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "URL\tSTATUS\tLATENCY")
+	for _, r := range results {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", r.URL, r.Status, r.latency.Round(time.Millisecond))
+	}
+	w.Flush()
+}
+
+func printCheckResultsJSON(results []checkResult) {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	enc.Encode(results)
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/mcpherrinm/hrmm/internal/fetcher"
+)
+
+func TestCheckResult_JSONLatencyIsMilliseconds(t *testing.T) {
+	r := checkResult{URL: "http://example.invalid", Status: "ok", LatencyMS: ms(12500 * time.Microsecond)}
+	if r.LatencyMS != 12.5 {
+		t.Errorf("expected 12.5ms, got %f", r.LatencyMS)
+	}
+}
+
+func TestCheckHealthy_ProbePathViaClientFor(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/-/healthy" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client, err := (&fetcher.HTTPClientConfig{}).ClientFor(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error building client: %v", err)
+	}
+
+	resp, err := client.Get(server.URL + "/-/healthy")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected 200, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mcpherrinm/hrmm/internal/fetcher"
+	"github.com/mcpherrinm/hrmm/internal/influx"
+	"github.com/spf13/cobra"
+)
+
+var (
+	influxDBURL       string
+	measurementPrefix string
+	flushInterval     time.Duration
+	exportDryRun      bool
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export polled metrics to external systems",
+}
+
+var exportInfluxDBCmd = &cobra.Command{
+	Use:   "influxdb",
+	Short: "Poll configured endpoints and write samples to InfluxDB as line protocol",
+	Long:  "Runs the same polling loop as graph, but instead of a TUI batches each poll's samples into InfluxDB line protocol and POSTs them to --influxdb-url every --flush-interval.",
+	Run: func(cmd *cobra.Command, args []string) {
+		runExportInfluxDB()
+	},
+}
+
+func init() {
+	exportInfluxDBCmd.Flags().StringVar(&influxDBURL, "influxdb-url", "", "InfluxDB /write endpoint to POST line protocol to (required unless --dry-run)")
+	exportInfluxDBCmd.Flags().StringVar(&measurementPrefix, "measurement-prefix", "", "prefix prepended to each metric name to form the InfluxDB measurement")
+	exportInfluxDBCmd.Flags().DurationVar(&flushInterval, "flush-interval", 10*time.Second, "how often to batch and flush samples to InfluxDB")
+	exportInfluxDBCmd.Flags().BoolVar(&exportDryRun, "dry-run", false, "print line protocol to stdout instead of POSTing it, e.g. to pipe into telegraf --test")
+
+	exportCmd.AddCommand(exportInfluxDBCmd)
+	rootCmd.AddCommand(exportCmd)
+}
+
+// runExportInfluxDB polls every configured endpoint on pollInterval and
+// batches samples into InfluxDB line protocol, flushing the batch on
+// flushInterval.
+func runExportInfluxDB() {
+	if influxDBURL == "" && !exportDryRun {
+		fmt.Println("Error: --influxdb-url is required unless --dry-run is set")
+		os.Exit(1)
+	}
+
+	var fetchers []*fetcher.MetricsFetcher
+	for _, url := range urls {
+		fetchers = append(fetchers, fetcher.New(url, metrics, labels))
+	}
+
+	var batch []string
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		lines := strings.Join(batch, "\n")
+		batch = batch[:0]
+
+		if exportDryRun {
+			fmt.Println(lines)
+			return
+		}
+		if err := postLineProtocolWithRetry(influxDBURL, lines); err != nil {
+			fmt.Printf("Warning: dropping batch: %v\n", err)
+		}
+	}
+
+	pollTicker := time.NewTicker(pollInterval)
+	defer pollTicker.Stop()
+	flushTicker := time.NewTicker(flushInterval)
+	defer flushTicker.Stop()
+
+	for {
+		select {
+		case <-pollTicker.C:
+			now := time.Now()
+			for _, f := range fetchers {
+				data, err := f.Fetch()
+				if err != nil {
+					fmt.Printf("Warning: fetch failed: %v\n", err)
+					continue
+				}
+				for _, m := range data {
+					measurement := measurementPrefix + m.Name
+					batch = append(batch, influx.EncodeLine(measurement, m.Labels, float64(m.Value), now))
+				}
+			}
+		case <-flushTicker.C:
+			flush()
+		}
+	}
+}
+
+// postLineProtocolWithRetry POSTs line protocol to the InfluxDB write
+// endpoint, retrying 5xx responses with exponential backoff. A 4xx
+// response means the batch itself is malformed, so it's dropped (with
+// a warning from the caller) rather than retried.
+func postLineProtocolWithRetry(url, lines string) error {
+	backoff := 100 * time.Millisecond
+	const maxAttempts = 5
+
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		resp, err := http.Post(url, "text/plain; charset=utf-8", bytes.NewReader([]byte(lines)))
+		if err != nil {
+			if attempt == maxAttempts {
+				return err
+			}
+			time.Sleep(backoff)
+			backoff *= 2
+			continue
+		}
+		resp.Body.Close()
+
+		switch {
+		case resp.StatusCode >= 200 && resp.StatusCode < 300:
+			return nil
+		case resp.StatusCode >= 400 && resp.StatusCode < 500:
+			return fmt.Errorf("influxdb rejected batch: %s", resp.Status)
+		default:
+			if attempt == maxAttempts {
+				return fmt.Errorf("influxdb returned %s after %d attempts", resp.Status, attempt)
+			}
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+	return fmt.Errorf("exhausted retries")
+}
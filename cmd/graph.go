@@ -1,10 +1,13 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/NimbleMarkets/ntcharts/linechart/timeserieslinechart"
@@ -20,25 +23,96 @@ import (
 type tickMsg time.Time
 type metricsMsg struct {
 	data []fetcher.MetricData
-	err  error
+	errs map[string]error // keyed by endpoint URL; one server failing doesn't drop the others' data
 }
 
-// metricGraph holds the data and chart for a single metric
+// streamMsg wraps one fetcher.StreamMsg delivered by a StreamFetcher's
+// channel, the push-based analogue of metricsMsg: it arrives out of
+// band, without a preceding tickMsg. url identifies which stream it
+// came from (the streaming analogue of errs' keying by endpoint), ch is
+// the channel itself so Update can re-arm listening for the next value,
+// and closed is set once the channel has been closed (ctx cancelled),
+// telling Update not to re-arm.
+type streamMsg struct {
+	url    string
+	msg    fetcher.StreamMsg
+	ch     <-chan fetcher.StreamMsg
+	closed bool
+}
+
+// listenStream returns a tea.Cmd that blocks until the next value
+// arrives on ch, wrapping it as a streamMsg so Update can process it
+// and re-arm listening for the one after it.
+func listenStream(url string, ch <-chan fetcher.StreamMsg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-ch
+		return streamMsg{url: url, msg: msg, ch: ch, closed: !ok}
+	}
+}
+
+// metricGraph holds the data and chart for a single metric. buffer
+// feeds the visible line chart and is capped at 30 points; summary
+// tracks approximate percentiles across the whole session, unbounded
+// by that window.
 type metricGraph struct {
-	name   string
-	buffer *buffer.RingBuffer
-	chart  timeserieslinechart.Model
+	name       string
+	alias      string // display name from --alias, falls back to name when empty
+	metricType string // Prometheus TYPE (counter, gauge, ...), learned from the first sample seen
+	buffer     *buffer.RingBuffer
+	summary    *buffer.Summary
+	chart      timeserieslinechart.Model
+	aggregator *buffer.Aggregator // nil unless --aggregate is set; downsamples before buffer/summary/chart
 }
 
-// metricItem implements list.Item for MetricData
+// AggregationConfig configures optional time-windowed downsampling of
+// plain (non-histogram) metric samples before they reach a graph's
+// RingBuffer/Summary/chart. A zero Window disables aggregation: samples
+// are pushed straight through on every tick, as before.
+type AggregationConfig struct {
+	Window time.Duration
+	Func   buffer.AggregateFunc
+	Grace  time.Duration
+	Delay  time.Duration
+}
+
+// parseAggregateFunc parses the --aggregate-func flag value into a
+// buffer.AggregateFunc, rejecting anything unrecognized.
+func parseAggregateFunc(raw string) (buffer.AggregateFunc, error) {
+	switch fn := buffer.AggregateFunc(raw); fn {
+	case buffer.AggregateAvg, buffer.AggregateMin, buffer.AggregateMax, buffer.AggregateLast:
+		return fn, nil
+	default:
+		return "", fmt.Errorf("invalid --aggregate-func %q: expected avg, min, max, or last", raw)
+	}
+}
+
+// label returns the name this graph should be rendered under: its
+// alias if one was configured, otherwise the raw metric name.
+func (g *metricGraph) label() string {
+	if g.alias != "" {
+		return g.alias
+	}
+	return g.name
+}
+
+// metricItem implements list.Item for MetricData. A histogram metric's
+// `_bucket`/`_sum`/`_count` companion series are collapsed into a single
+// selectable entry named after the base metric, with isHistogram set.
 type metricItem struct {
-	metric   fetcher.MetricData
-	selected bool
+	metric      fetcher.MetricData
+	selected    bool
+	isHistogram bool
+	upperBounds []float64 // set when isHistogram, ascending le boundaries
 }
 
 func (i metricItem) FilterValue() string { return i.metric.Identifier() }
 
-func (i metricItem) Title() string { return i.metric.Identifier() }
+func (i metricItem) Title() string {
+	if i.isHistogram {
+		return i.metric.Name + " (histogram)"
+	}
+	return i.metric.Identifier()
+}
 
 func (i metricItem) Description() string {
 	selected := " "
@@ -50,10 +124,14 @@ func (i metricItem) Description() string {
 
 // metricSelectionModel represents the metric selection screen using bubbles/list
 type metricSelectionModel struct {
-	list     list.Model
-	err      error
-	fetchers []*fetcher.MetricsFetcher
-	interval time.Duration
+	list         list.Model
+	err          error
+	fetchers     []*fetcher.MetricsFetcher
+	interval     time.Duration
+	aliases      []MetricSpec
+	fetchTimeout time.Duration
+	aggregation  AggregationConfig
+	streams      []*fetcher.StreamFetcher
 }
 
 func (m *metricSelectionModel) Init() tea.Cmd {
@@ -85,7 +163,8 @@ func (m *metricSelectionModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 			if len(selectedMetrics) > 0 {
-				return newDashboardModel(selectedMetrics, m.fetchers, m.interval), nil
+				histogramBounds := histogramBoundsFromItems(m.list.Items())
+				return newDashboardModel(selectedMetrics, m.fetchers, m.interval, histogramBounds, m.aliases, m.fetchTimeout, m.aggregation, m.streams), nil
 			}
 		}
 	}
@@ -103,12 +182,31 @@ func (m *metricSelectionModel) View() string {
 type dashboardModel struct {
 	selectedMetrics []string
 	graphs          map[string]*metricGraph
+	histograms      map[string]*histogramGraph
 	width           int
 	height          int
 	fetchers        []*fetcher.MetricsFetcher
 	interval        time.Duration
+	fetchTimeout    time.Duration
 	lastFetch       time.Time
-	lastError       error
+	lastErrors      map[string]error // keyed by endpoint URL
+	streams         []*fetcher.StreamFetcher
+	streamStates    map[string]string // keyed by stream URL: "connected", "reconnecting in Ns", ...
+	aliases         []MetricSpec
+}
+
+// aggregationDropped sums the samples dropped by every graph's
+// Aggregator (out-of-tolerance timestamps, see buffer.Aggregator). It's
+// 0 whenever --aggregate isn't set, since graphs then have no
+// aggregator at all.
+func (m dashboardModel) aggregationDropped() int {
+	dropped := 0
+	for _, graph := range m.graphs {
+		if graph.aggregator != nil {
+			dropped += graph.aggregator.Dropped
+		}
+	}
+	return dropped
 }
 
 // calculateGrid returns the number of columns and rows for the grid layout
@@ -125,20 +223,58 @@ func (m dashboardModel) calculateGrid() (cols, rows int) {
 	return cols, rows
 }
 
-func newDashboardModel(metrics []string, fetchers []*fetcher.MetricsFetcher, interval time.Duration) dashboardModel {
+// newDashboardModel builds the dashboard for the given metric names.
+// histogramBounds, if it has an entry for a given metric name, causes
+// that metric to be tracked as a histogramGraph (rendered as a heatmap)
+// instead of a plain metricGraph line chart. aliases supplies display
+// names from --alias; a metric with no matching spec is shown under
+// its raw name. Since graphs are keyed by metric name alone (shared
+// across whichever endpoints report it), an endpoint-scoped alias spec
+// is re-resolved against each incoming sample's real endpoint in
+// ingestData rather than fixed at creation, so the label reflects
+// whichever endpoint most recently reported that metric. fetchTimeout
+// bounds how long a single tick's scrape of
+// an endpoint may take; a zero value means no bound tighter than
+// interval itself. aggregation, if its Window is non-zero, downsamples
+// each plain graph's samples into one point per window instead of
+// pushing every tick straight through. streams, if non-empty, are
+// connected in Init and push samples into the same graphs out of band,
+// alongside the poll-driven fetchers.
+func newDashboardModel(metrics []string, fetchers []*fetcher.MetricsFetcher, interval time.Duration, histogramBounds map[string][]float64, aliases []MetricSpec, fetchTimeout time.Duration, aggregation AggregationConfig, streams []*fetcher.StreamFetcher) dashboardModel {
 	graphs := make(map[string]*metricGraph)
+	histograms := make(map[string]*histogramGraph)
 	for _, name := range metrics {
-		graphs[name] = &metricGraph{
-			name:   name,
-			buffer: buffer.New(30),
-			chart:  timeserieslinechart.New(40, 10), // default size, will be resized
+		if bounds, ok := histogramBounds[name]; ok {
+			histograms[name] = newHistogramGraph(name, bounds, 30)
+			continue
+		}
+		alias, _ := resolveAlias(aliases, "", name)
+		graph := &metricGraph{
+			name:    name,
+			alias:   alias,
+			buffer:  buffer.New(30),
+			summary: buffer.NewSummary(),
+			chart:   timeserieslinechart.New(40, 10), // default size, will be resized
+		}
+		if aggregation.Window > 0 {
+			graph.aggregator = buffer.NewAggregator(aggregation.Window, aggregation.Func, aggregation.Grace, aggregation.Delay, func(t time.Time, value float64) {
+				graph.buffer.PushAt(t, value)
+				graph.summary.Insert(value)
+				graph.chart.Push(timeserieslinechart.TimePoint{Time: t, Value: value})
+				graph.chart.DrawBraille()
+			})
 		}
+		graphs[name] = graph
 	}
 	return dashboardModel{
 		selectedMetrics: metrics,
 		graphs:          graphs,
+		histograms:      histograms,
 		fetchers:        fetchers,
 		interval:        interval,
+		fetchTimeout:    fetchTimeout,
+		streams:         streams,
+		aliases:         aliases,
 	}
 }
 
@@ -148,22 +284,54 @@ func (m dashboardModel) pollTick() tea.Cmd {
 	})
 }
 
+// fetchMetrics fans out to every configured endpoint concurrently,
+// bounding each scrape to at most fetchTimeout (capped at interval, so
+// a hung endpoint can never delay the next pollTick by more than one
+// interval). A failure on one endpoint, including a timeout, is
+// recorded in the returned metricsMsg's errs map rather than
+// discarding the other endpoints' successful data.
 func (m dashboardModel) fetchMetrics() tea.Cmd {
 	return func() tea.Msg {
+		timeout := m.interval
+		if m.fetchTimeout > 0 && m.fetchTimeout < timeout {
+			timeout = m.fetchTimeout
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
 		var allData []fetcher.MetricData
+		errs := make(map[string]error)
+
 		for _, f := range m.fetchers {
-			data, err := f.Fetch()
-			if err != nil {
-				return metricsMsg{data: nil, err: err}
-			}
-			allData = append(allData, data...)
+			wg.Add(1)
+			go func(f *fetcher.MetricsFetcher) {
+				defer wg.Done()
+				data, err := f.FetchContext(ctx)
+
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					errs[f.URL()] = err
+					return
+				}
+				allData = append(allData, data...)
+			}(f)
 		}
-		return metricsMsg{data: allData, err: nil}
+		wg.Wait()
+
+		return metricsMsg{data: allData, errs: errs}
 	}
 }
 
 func (m dashboardModel) Init() tea.Cmd {
-	return tea.Batch(m.pollTick(), m.fetchMetrics())
+	cmds := []tea.Cmd{m.pollTick(), m.fetchMetrics()}
+	for _, s := range m.streams {
+		ch := s.Connect(context.Background())
+		cmds = append(cmds, listenStream(s.URL(), ch))
+	}
+	return tea.Batch(cmds...)
 }
 
 func (m dashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -199,33 +367,83 @@ func (m dashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, m.fetchMetrics()
 	case metricsMsg:
 		m.lastFetch = time.Now()
-		if msg.err != nil {
-			m.lastError = msg.err
-		} else {
-			m.lastError = nil
-			for _, metric := range msg.data {
-				if graph, ok := m.graphs[metric.Name]; ok {
-					value := float64(metric.Value)
-					// Skip NaN/Inf values
-					if math.IsNaN(value) || math.IsInf(value, 0) {
-						continue
-					}
-					graph.buffer.Push(value)
-					graph.chart.Push(timeserieslinechart.TimePoint{
-						Time:  m.lastFetch,
-						Value: value,
-					})
-					graph.chart.DrawBraille()
-				}
+		m.lastErrors = msg.errs
+		m.ingestData(m.lastFetch, msg.data)
+		return m, m.pollTick()
+	case streamMsg:
+		if msg.msg.State != "" {
+			if m.streamStates == nil {
+				m.streamStates = make(map[string]string)
 			}
+			m.streamStates[msg.url] = msg.msg.State
 		}
-		return m, m.pollTick()
+		if msg.msg.Data != nil {
+			m.ingestData(time.Now(), msg.msg.Data)
+		}
+		if msg.closed {
+			return m, nil // ctx done: stop listening, nothing left to re-arm
+		}
+		return m, listenStream(msg.url, msg.ch)
 	}
 	return m, nil
 }
 
+// ingestData routes one batch of freshly-arrived samples, as of
+// timestamp t, into the matching histograms and metricGraphs. It's
+// shared by the poll-based metricsMsg and the push-based streamMsg
+// handlers, which differ only in how t and the batch were obtained.
+func (m dashboardModel) ingestData(t time.Time, data []fetcher.MetricData) {
+	histTicks := make(map[string]map[float64]float64) // base -> le -> cumulative count
+	for _, metric := range data {
+		if base, le, ok := histogramBucketName(metric.Name, metric.Labels); ok {
+			if _, tracked := m.histograms[base]; tracked {
+				if histTicks[base] == nil {
+					histTicks[base] = make(map[float64]float64)
+				}
+				histTicks[base][le] = float64(metric.Value)
+			}
+			continue
+		}
+		if graph, ok := m.graphs[metric.Name]; ok {
+			value := float64(metric.Value)
+			// Skip NaN/Inf values
+			if math.IsNaN(value) || math.IsInf(value, 0) {
+				continue
+			}
+			if graph.metricType == "" {
+				graph.metricType = metric.Type
+			}
+			// An empty alias (no spec for this endpoint) falls back to the raw name.
+			graph.alias, _ = resolveAlias(m.aliases, metric.Endpoint, metric.Name)
+			if graph.aggregator != nil {
+				graph.aggregator.Push(t, value)
+			} else {
+				graph.buffer.PushAt(t, value)
+				graph.summary.Insert(value)
+				graph.chart.Push(timeserieslinechart.TimePoint{
+					Time:  t,
+					Value: value,
+				})
+				graph.chart.DrawBraille()
+			}
+		}
+	}
+	for base, byBound := range histTicks {
+		hg := m.histograms[base]
+		buckets := make([]float64, len(hg.upperBounds))
+		for i, bound := range hg.upperBounds {
+			buckets[i] = byBound[bound]
+		}
+		hg.Push(buckets)
+	}
+}
+
 // renderMetricCell renders a single metric's label and chart as a cell
 func (m dashboardModel) renderMetricCell(name string) string {
+	if _, ok := m.histograms[name]; ok {
+		return m.renderHistogramCell(name)
+	}
+
 	graph, ok := m.graphs[name]
 	if !ok {
 		return ""
@@ -233,9 +451,25 @@ func (m dashboardModel) renderMetricCell(name string) string {
 
 	var label string
 	if val, ok := graph.buffer.Latest(); ok {
-		label = fmt.Sprintf("%s: %.2f (points: %d)", name, val, graph.buffer.Len())
+		label = fmt.Sprintf("%s: %.2f (points: %d)", graph.label(), val, graph.buffer.Len())
+		if p50, ok := graph.summary.Query(0.5); ok {
+			p95, _ := graph.summary.Query(0.95)
+			p99, _ := graph.summary.Query(0.99)
+			label += fmt.Sprintf(" | p50/p95/p99 (session): %.2f/%.2f/%.2f", p50, p95, p99)
+		}
+		if graph.metricType == "counter" {
+			if rate, ok := graph.buffer.Rate(); ok {
+				label += fmt.Sprintf(" | rate: %.2f/s", rate)
+			}
+			if increase, ok := graph.buffer.Increase(); ok {
+				label += fmt.Sprintf(" | increase: %.2f", increase)
+			}
+			if resets := graph.buffer.Resets(); resets > 0 {
+				label += fmt.Sprintf(" | resets: %d", resets)
+			}
+		}
 	} else {
-		label = fmt.Sprintf("%s: (no data)", name)
+		label = fmt.Sprintf("%s: (no data)", graph.label())
 	}
 
 	return label + "\n" + graph.chart.View()
@@ -248,10 +482,38 @@ func (m dashboardModel) View() string {
 		s += fmt.Sprintf("Last fetch: %s ago | ", time.Since(m.lastFetch).Round(time.Second))
 	}
 	cols, _ := m.calculateGrid()
-	s += fmt.Sprintf("Metrics: %d | Grid: %d cols\n\n", len(m.graphs), cols)
+	s += fmt.Sprintf("Metrics: %d | Grid: %d cols", len(m.graphs)+len(m.histograms), cols)
+	if dropped := m.aggregationDropped(); dropped > 0 {
+		s += fmt.Sprintf(" | Dropped: %d", dropped)
+	}
+	s += "\n\n"
 
-	if m.lastError != nil {
-		s += fmt.Sprintf("âš  Error: %v\n\n", m.lastError)
+	if len(m.lastErrors) > 0 {
+		var statuses []string
+		for _, f := range m.fetchers {
+			if err, ok := m.lastErrors[f.URL()]; ok {
+				status := err.Error()
+				if errors.Is(err, context.DeadlineExceeded) {
+					status = "timed out"
+				}
+				statuses = append(statuses, fmt.Sprintf("%s: %s", f.URL(), status))
+			}
+		}
+		if len(statuses) > 0 {
+			s += fmt.Sprintf("\u26a0 %s\n\n", strings.Join(statuses, " | "))
+		}
+	}
+
+	if len(m.streamStates) > 0 {
+		var statuses []string
+		for _, src := range m.streams {
+			if state, ok := m.streamStates[src.URL()]; ok {
+				statuses = append(statuses, fmt.Sprintf("%s: %s", src.URL(), state))
+			}
+		}
+		if len(statuses) > 0 {
+			s += fmt.Sprintf("Stream: %s\n\n", strings.Join(statuses, " | "))
+		}
 	}
 
 	// Handle case where we haven't received WindowSizeMsg yet
@@ -307,10 +569,61 @@ var graphCmd = &cobra.Command{
 	Short: "Display metrics in a graph/TUI format",
 	Long:  "Poll prometheus metrics endpoints and display the results in a graph or TUI format.",
 	Run: func(cmd *cobra.Command, args []string) {
+		aliases, err := parseAliasSpecs(aliasFlags)
+		if err != nil {
+			fmt.Printf("Error parsing --alias: %v\n", err)
+			os.Exit(1)
+		}
+
+		aggregateFn, err := parseAggregateFunc(aggregateFunc)
+		if err != nil {
+			fmt.Printf("Error parsing --aggregate-func: %v\n", err)
+			os.Exit(1)
+		}
+		aggregation := AggregationConfig{
+			Window: aggregateWindow,
+			Func:   aggregateFn,
+			Grace:  aggregateGrace,
+			Delay:  aggregateDelay,
+		}
+
 		// Create fetchers for all URLs
+		var httpConfig *fetcher.HTTPClientConfig
+		if httpConfigFile != "" {
+			var err error
+			httpConfig, err = fetcher.LoadHTTPClientConfig(httpConfigFile)
+			if err != nil {
+				fmt.Printf("Error loading --http.config.file: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
 		var fetchers []*fetcher.MetricsFetcher
 		for _, url := range urls {
-			fetchers = append(fetchers, fetcher.New(url, metrics, labels))
+			if httpConfig == nil {
+				fetchers = append(fetchers, fetcher.New(url, metrics, labels))
+				continue
+			}
+			client, err := httpConfig.ClientFor(url)
+			if err != nil {
+				fmt.Printf("Error building HTTP client for %s: %v\n", url, err)
+				os.Exit(1)
+			}
+			fetchers = append(fetchers, fetcher.NewWithClient(url, metrics, labels, client))
+		}
+
+		var streams []*fetcher.StreamFetcher
+		for _, url := range streamURLs {
+			if httpConfig == nil {
+				streams = append(streams, fetcher.NewStreamFetcher(url, metrics, labels))
+				continue
+			}
+			client, err := httpConfig.ClientFor(url)
+			if err != nil {
+				fmt.Printf("Error building HTTP client for %s: %v\n", url, err)
+				os.Exit(1)
+			}
+			streams = append(streams, fetcher.NewStreamFetcherWithClient(url, metrics, labels, client))
 		}
 
 		// Fetch metrics from all URLs for initial picker display
@@ -329,14 +642,9 @@ var graphCmd = &cobra.Command{
 			return
 		}
 
-		// Convert metrics to list items
-		items := make([]list.Item, len(allMetrics))
-		for i, metric := range allMetrics {
-			items[i] = metricItem{
-				metric:   metric,
-				selected: false,
-			}
-		}
+		// Convert metrics to list items, collapsing each histogram's
+		// `_bucket`/`_sum`/`_count` companion series into one entry.
+		items := buildMetricItems(allMetrics)
 
 		l := list.New(items, list.NewDefaultDelegate(), 80, 25)
 		l.Title = "Select metrics to graph"
@@ -345,9 +653,13 @@ var graphCmd = &cobra.Command{
 		l.Styles.Title = l.Styles.Title.Foreground(list.DefaultStyles().Title.GetForeground())
 
 		p := tea.NewProgram(&metricSelectionModel{
-			list:     l,
-			fetchers: fetchers,
-			interval: pollInterval,
+			list:         l,
+			fetchers:     fetchers,
+			interval:     pollInterval,
+			aliases:      aliases,
+			fetchTimeout: fetchTimeout,
+			aggregation:  aggregation,
+			streams:      streams,
 		}, tea.WithAltScreen())
 
 		if _, err := p.Run(); err != nil {
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -9,11 +10,12 @@ import (
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mcpherrinm/hrmm/internal/buffer"
 	"github.com/mcpherrinm/hrmm/internal/fetcher"
 )
 
 func TestDashboardModel_TickMsgTriggersFetch(t *testing.T) {
-	model := newDashboardModel([]string{"test_metric"}, nil, time.Second, 80, 24)
+	model := newDashboardModel([]string{"test_metric"}, nil, time.Second, nil, nil, 0, AggregationConfig{}, nil)
 
 	msg := tickMsg(time.Now())
 	_, cmd := model.Update(msg)
@@ -25,13 +27,13 @@ func TestDashboardModel_TickMsgTriggersFetch(t *testing.T) {
 
 func TestDashboardModel_MetricsMsgUpdatesState(t *testing.T) {
 	// Create model with initialized graphs
-	model := newDashboardModel([]string{"test_metric"}, nil, time.Second, 80, 24)
+	model := newDashboardModel([]string{"test_metric"}, nil, time.Second, nil, nil, 0, AggregationConfig{}, nil)
 
 	testData := []fetcher.MetricData{
 		{Name: "test_metric", Value: fetcher.NullableFloat64(42.0)},
 	}
 
-	msg := metricsMsg{data: testData, err: nil}
+	msg := metricsMsg{data: testData, errs: nil}
 	result, cmd := model.Update(msg)
 
 	dm := result.(dashboardModel)
@@ -49,8 +51,8 @@ func TestDashboardModel_MetricsMsgUpdatesState(t *testing.T) {
 		t.Error("expected lastFetch to be set")
 	}
 
-	if dm.lastError != nil {
-		t.Errorf("expected nil error, got %v", dm.lastError)
+	if len(dm.lastErrors) != 0 {
+		t.Errorf("expected no errors, got %v", dm.lastErrors)
 	}
 
 	// Should return pollTick command to continue polling
@@ -60,20 +62,20 @@ func TestDashboardModel_MetricsMsgUpdatesState(t *testing.T) {
 }
 
 func TestDashboardModel_MetricsMsgError(t *testing.T) {
-	model := newDashboardModel([]string{"test_metric"}, nil, time.Second, 80, 24)
+	model := newDashboardModel([]string{"test_metric"}, nil, time.Second, nil, nil, 0, AggregationConfig{}, nil)
 
 	testErr := errors.New("connection refused")
-	msg := metricsMsg{data: nil, err: testErr}
+	msg := metricsMsg{data: nil, errs: map[string]error{"http://server1": testErr}}
 
 	result, cmd := model.Update(msg)
 	dm := result.(dashboardModel)
 
-	if dm.lastError == nil {
-		t.Error("expected lastError to be set")
+	if dm.lastErrors["http://server1"] == nil {
+		t.Error("expected lastErrors to be set for server1")
 	}
 
-	if dm.lastError.Error() != "connection refused" {
-		t.Errorf("expected 'connection refused', got '%v'", dm.lastError)
+	if dm.lastErrors["http://server1"].Error() != "connection refused" {
+		t.Errorf("expected 'connection refused', got '%v'", dm.lastErrors["http://server1"])
 	}
 
 	// Should continue polling despite error
@@ -84,25 +86,119 @@ func TestDashboardModel_MetricsMsgError(t *testing.T) {
 
 func TestDashboardModel_MetricsMsgClearsError(t *testing.T) {
 	// Start with an existing error
-	model := newDashboardModel([]string{"test_metric"}, nil, time.Second, 80, 24)
-	model.lastError = errors.New("previous error")
+	model := newDashboardModel([]string{"test_metric"}, nil, time.Second, nil, nil, 0, AggregationConfig{}, nil)
+	model.lastErrors = map[string]error{"http://server1": errors.New("previous error")}
 
-	// Send successful metrics
+	// Send successful metrics with no errors this tick
 	testData := []fetcher.MetricData{
 		{Name: "test_metric", Value: fetcher.NullableFloat64(42.0)},
 	}
-	msg := metricsMsg{data: testData, err: nil}
+	msg := metricsMsg{data: testData, errs: map[string]error{}}
 
 	result, _ := model.Update(msg)
 	dm := result.(dashboardModel)
 
-	if dm.lastError != nil {
-		t.Errorf("expected lastError to be cleared, got %v", dm.lastError)
+	if len(dm.lastErrors) != 0 {
+		t.Errorf("expected lastErrors to be cleared, got %v", dm.lastErrors)
+	}
+}
+
+func TestDashboardModel_MetricsMsgPartialFailureKeepsGoodData(t *testing.T) {
+	model := newDashboardModel([]string{"test_metric"}, nil, time.Second, nil, nil, 0, AggregationConfig{}, nil)
+
+	testData := []fetcher.MetricData{
+		{Name: "test_metric", Value: fetcher.NullableFloat64(7.0)},
+	}
+	msg := metricsMsg{
+		data: testData,
+		errs: map[string]error{"http://bad-server": errors.New("connection refused")},
+	}
+
+	result, _ := model.Update(msg)
+	dm := result.(dashboardModel)
+
+	if graph, ok := dm.graphs["test_metric"]; !ok || graph.buffer.Len() != 1 {
+		t.Error("expected data from the healthy endpoint to still be recorded")
+	}
+	if dm.lastErrors["http://bad-server"] == nil {
+		t.Error("expected the failing endpoint's error to be recorded")
+	}
+}
+
+func TestDashboardModel_StreamMsgUpdatesStateOutOfBand(t *testing.T) {
+	model := newDashboardModel([]string{"test_metric"}, nil, time.Second, nil, nil, 0, AggregationConfig{}, []*fetcher.StreamFetcher{
+		fetcher.NewStreamFetcher("http://stream1", nil, nil),
+	})
+
+	ch := make(chan fetcher.StreamMsg)
+	msg := streamMsg{
+		url: "http://stream1",
+		msg: fetcher.StreamMsg{Data: []fetcher.MetricData{
+			{Name: "test_metric", Value: fetcher.NullableFloat64(3.0)},
+		}},
+		ch: ch,
+	}
+
+	result, cmd := model.Update(msg)
+	dm := result.(dashboardModel)
+
+	// A streamMsg must update graphs without any preceding tickMsg.
+	if graph, ok := dm.graphs["test_metric"]; !ok || graph.buffer.Len() != 1 {
+		t.Error("expected the stream's data to be recorded out of band")
+	}
+	if cmd == nil {
+		t.Error("expected Update to re-arm listening for the next stream value")
+	}
+}
+
+func TestDashboardModel_StreamMsgTracksConnectionState(t *testing.T) {
+	model := newDashboardModel([]string{"test_metric"}, nil, time.Second, nil, nil, 0, AggregationConfig{}, []*fetcher.StreamFetcher{
+		fetcher.NewStreamFetcher("http://stream1", nil, nil),
+	})
+
+	ch := make(chan fetcher.StreamMsg)
+	result, _ := model.Update(streamMsg{url: "http://stream1", msg: fetcher.StreamMsg{State: "connected"}, ch: ch})
+	dm := result.(dashboardModel)
+
+	if dm.streamStates["http://stream1"] != "connected" {
+		t.Errorf("expected stream state \"connected\", got %q", dm.streamStates["http://stream1"])
+	}
+
+	result, _ = dm.Update(streamMsg{url: "http://stream1", msg: fetcher.StreamMsg{State: "reconnecting in 1s"}, ch: ch})
+	dm = result.(dashboardModel)
+	if dm.streamStates["http://stream1"] != "reconnecting in 1s" {
+		t.Errorf("expected stream state \"reconnecting in 1s\", got %q", dm.streamStates["http://stream1"])
+	}
+}
+
+func TestDashboardModel_StreamMsgClosedDoesNotReArm(t *testing.T) {
+	model := newDashboardModel([]string{"test_metric"}, nil, time.Second, nil, nil, 0, AggregationConfig{}, nil)
+
+	ch := make(chan fetcher.StreamMsg)
+	close(ch)
+	_, cmd := model.Update(streamMsg{url: "http://stream1", ch: ch, closed: true})
+
+	if cmd != nil {
+		t.Error("expected no re-arm command once the stream's channel is closed")
+	}
+}
+
+func TestDashboardModel_ViewShowsStreamState(t *testing.T) {
+	model := newDashboardModel([]string{"test_metric"}, nil, time.Second, nil, nil, 0, AggregationConfig{}, []*fetcher.StreamFetcher{
+		fetcher.NewStreamFetcher("http://stream1", nil, nil),
+	})
+	model.width = 80
+	model.height = 24
+	model.streamStates = map[string]string{"http://stream1": "reconnecting in 2s"}
+
+	view := model.View()
+	if !containsString(view, "reconnecting in 2s") {
+		t.Error("expected the view to surface the stream's connection state")
 	}
 }
 
 func TestDashboardModel_InitReturnsBatch(t *testing.T) {
-	model := newDashboardModel([]string{"test_metric"}, nil, time.Second, 80, 24)
+	model := newDashboardModel([]string{"test_metric"}, nil, time.Second, nil, nil, 0, AggregationConfig{}, nil)
 
 	cmd := model.Init()
 
@@ -115,7 +211,7 @@ func TestDashboardModel_InitReturnsBatch(t *testing.T) {
 }
 
 func TestDashboardModel_QuitOnCtrlC(t *testing.T) {
-	model := newDashboardModel([]string{"test_metric"}, nil, time.Second, 80, 24)
+	model := newDashboardModel([]string{"test_metric"}, nil, time.Second, nil, nil, 0, AggregationConfig{}, nil)
 
 	msg := tea.KeyMsg{Type: tea.KeyCtrlC}
 	_, cmd := model.Update(msg)
@@ -127,7 +223,7 @@ func TestDashboardModel_QuitOnCtrlC(t *testing.T) {
 }
 
 func TestDashboardModel_QuitOnQ(t *testing.T) {
-	model := newDashboardModel([]string{"test_metric"}, nil, time.Second, 80, 24)
+	model := newDashboardModel([]string{"test_metric"}, nil, time.Second, nil, nil, 0, AggregationConfig{}, nil)
 
 	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}}
 	_, cmd := model.Update(msg)
@@ -138,7 +234,7 @@ func TestDashboardModel_QuitOnQ(t *testing.T) {
 }
 
 func TestDashboardModel_WindowSizeUpdates(t *testing.T) {
-	model := newDashboardModel([]string{"test_metric"}, nil, time.Second, 80, 24)
+	model := newDashboardModel([]string{"test_metric"}, nil, time.Second, nil, nil, 0, AggregationConfig{}, nil)
 
 	msg := tea.WindowSizeMsg{Width: 120, Height: 40}
 	result, _ := model.Update(msg)
@@ -187,11 +283,111 @@ test_counter %d
 	}
 }
 
+func TestFetchMetrics_AggregatesPartialFailureAcrossEndpoints(t *testing.T) {
+	good := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		fmt.Fprintf(w, "# TYPE test_metric gauge\ntest_metric 5\n")
+	}))
+	defer good.Close()
+
+	bad := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer bad.Close()
+
+	model := newDashboardModel(
+		[]string{"test_metric"},
+		[]*fetcher.MetricsFetcher{
+			fetcher.New(good.URL, nil, nil),
+			fetcher.New(bad.URL, nil, nil),
+		},
+		time.Second,
+		nil,
+		nil,
+		0,
+		AggregationConfig{},
+		nil,
+	)
+
+	cmd := model.fetchMetrics()
+	msg, ok := cmd().(metricsMsg)
+	if !ok {
+		t.Fatal("expected fetchMetrics to return a metricsMsg")
+	}
+
+	if len(msg.data) != 1 {
+		t.Fatalf("expected data from the healthy server, got %d samples", len(msg.data))
+	}
+	if _, ok := msg.errs[bad.URL]; !ok {
+		t.Errorf("expected an error recorded for %s", bad.URL)
+	}
+	if _, ok := msg.errs[good.URL]; ok {
+		t.Error("did not expect an error recorded for the healthy server")
+	}
+}
+
+func TestFetchMetrics_TimesOutSlowEndpointWithoutStallingPoll(t *testing.T) {
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.Write([]byte("test_metric 1\n"))
+	}))
+	defer slow.Close()
+
+	model := newDashboardModel(
+		[]string{"test_metric"},
+		[]*fetcher.MetricsFetcher{fetcher.New(slow.URL, nil, nil)},
+		time.Second,
+		nil,
+		nil,
+		50*time.Millisecond,
+		AggregationConfig{},
+		nil,
+	)
+
+	start := time.Now()
+	cmd := model.fetchMetrics()
+	msg, ok := cmd().(metricsMsg)
+	elapsed := time.Since(start)
+
+	if !ok {
+		t.Fatal("expected fetchMetrics to return a metricsMsg")
+	}
+	if elapsed >= 200*time.Millisecond {
+		t.Errorf("expected fetchMetrics to return well before the slow handler's 200ms sleep, took %s", elapsed)
+	}
+	if !errors.Is(msg.errs[slow.URL], context.DeadlineExceeded) {
+		t.Errorf("expected a wrapped context.DeadlineExceeded for %s, got %v", slow.URL, msg.errs[slow.URL])
+	}
+
+	// The model must still be able to schedule the next poll.
+	result, pollCmd := model.Update(msg)
+	if pollCmd == nil {
+		t.Error("expected a pollTick command after a timed-out fetch")
+	}
+	dm := result.(dashboardModel)
+	if dm.lastErrors[slow.URL] == nil {
+		t.Error("expected the timeout to be recorded in lastErrors")
+	}
+}
+
+func TestDashboardModel_ViewShowsTimedOutStatus(t *testing.T) {
+	model := newDashboardModel([]string{"test_metric"}, []*fetcher.MetricsFetcher{fetcher.New("http://server1", nil, nil)}, time.Second, nil, nil, 0, AggregationConfig{}, nil)
+	model.width = 80
+	model.height = 24
+	model.lastErrors = map[string]error{"http://server1": fmt.Errorf("fetching http://server1: %w", context.DeadlineExceeded)}
+
+	view := model.View()
+
+	if !containsString(view, "timed out") {
+		t.Error("expected view to render a distinct 'timed out' status for a deadline-exceeded error")
+	}
+}
+
 func TestDashboardModel_ViewShowsError(t *testing.T) {
-	model := newDashboardModel([]string{"test_metric"}, nil, time.Second, 80, 24)
+	model := newDashboardModel([]string{"test_metric"}, []*fetcher.MetricsFetcher{fetcher.New("http://server1", nil, nil)}, time.Second, nil, nil, 0, AggregationConfig{}, nil)
 	model.width = 80
 	model.height = 24
-	model.lastError = errors.New("connection timeout")
+	model.lastErrors = map[string]error{"http://server1": errors.New("connection timeout")}
 
 	view := model.View()
 
@@ -199,15 +395,96 @@ func TestDashboardModel_ViewShowsError(t *testing.T) {
 		t.Error("expected non-empty view")
 	}
 
-	// Check that error is displayed
-	if !containsString(view, "Error") {
+	// Check that the per-endpoint error is displayed
+	if !containsString(view, "connection timeout") {
 		t.Error("expected view to contain error message")
 	}
 }
 
+func TestDashboardModel_ViewShowsAlias(t *testing.T) {
+	aliases := []MetricSpec{{Name: "cpu_usage", Alias: "API CPU"}}
+	model := newDashboardModel([]string{"cpu_usage"}, nil, time.Second, nil, aliases, 0, AggregationConfig{}, nil)
+	model.width = 80
+	model.height = 24
+	model.graphs["cpu_usage"].buffer.Push(42.0)
+
+	view := model.View()
+
+	if !containsString(view, "API CPU") {
+		t.Error("expected view to render the configured alias")
+	}
+	if containsString(view, "cpu_usage:") {
+		t.Error("expected the raw metric name to be replaced by its alias")
+	}
+}
+
+func TestDashboardModel_EndpointScopedAliasAppliesToMatchingSample(t *testing.T) {
+	aliases := []MetricSpec{
+		{Name: "cpu_usage", Alias: "CPU"},
+		{Name: "cpu_usage", Alias: "Host A CPU", Endpoint: "http://host-a"},
+	}
+	model := newDashboardModel([]string{"cpu_usage"}, nil, time.Second, nil, aliases, 0, AggregationConfig{}, nil)
+	model.width = 80
+	model.height = 24
+
+	testData := []fetcher.MetricData{
+		{Name: "cpu_usage", Value: fetcher.NullableFloat64(42.0), Endpoint: "http://host-a"},
+	}
+	result, _ := model.Update(metricsMsg{data: testData})
+	dm := result.(dashboardModel)
+
+	if !containsString(dm.View(), "Host A CPU") {
+		t.Error("expected the endpoint-scoped alias to apply once a sample from that endpoint arrives")
+	}
+
+	testData = []fetcher.MetricData{
+		{Name: "cpu_usage", Value: fetcher.NullableFloat64(43.0), Endpoint: "http://host-b"},
+	}
+	result, _ = dm.Update(metricsMsg{data: testData})
+	dm = result.(dashboardModel)
+
+	if !containsString(dm.View(), "CPU") || containsString(dm.View(), "Host A CPU") {
+		t.Error("expected the global alias to apply for an endpoint with no specific spec")
+	}
+}
+
+func TestDashboardModel_MetricsMsgAggregatesWithinWindow(t *testing.T) {
+	agg := AggregationConfig{Window: time.Minute, Func: buffer.AggregateAvg}
+	model := newDashboardModel([]string{"test_metric"}, nil, time.Second, nil, nil, 0, agg, nil)
+
+	push := func(v float64) dashboardModel {
+		msg := metricsMsg{data: []fetcher.MetricData{{Name: "test_metric", Value: fetcher.NullableFloat64(v)}}}
+		result, _ := model.Update(msg)
+		return result.(dashboardModel)
+	}
+
+	model = push(10)
+	model = push(20)
+
+	graph := model.graphs["test_metric"]
+	if graph.buffer.Len() != 0 {
+		t.Errorf("expected no point pushed to the buffer yet (still within the first window), got %d", graph.buffer.Len())
+	}
+}
+
+func TestDashboardModel_ViewShowsDroppedCount(t *testing.T) {
+	model := newDashboardModel([]string{"test_metric"}, nil, time.Second, nil, nil, 0, AggregationConfig{Window: time.Second}, nil)
+	model.width = 80
+	model.height = 24
+
+	graph := model.graphs["test_metric"]
+	graph.aggregator.Push(time.Unix(0, 0), 1)
+	graph.aggregator.Push(time.Unix(0, 0).Add(-time.Hour), 2) // wildly out of order: dropped
+
+	view := model.View()
+	if !containsString(view, "Dropped: 1") {
+		t.Error("expected the view to surface the aggregator's dropped-sample count")
+	}
+}
+
 func TestDashboardModel_ViewShowsMetrics(t *testing.T) {
 	// Create model with initialized graphs
-	model := newDashboardModel([]string{"cpu_usage", "memory_bytes"}, nil, time.Second, 80, 24)
+	model := newDashboardModel([]string{"cpu_usage", "memory_bytes"}, nil, time.Second, nil, nil, 0, AggregationConfig{}, nil)
 	model.width = 80
 	model.height = 24
 
@@ -264,7 +541,8 @@ func TestDashboardModel_CalculateGrid(t *testing.T) {
 			for i := 0; i < tc.numMetrics; i++ {
 				metrics[i] = fmt.Sprintf("metric_%d", i)
 			}
-			model := newDashboardModel(metrics, nil, time.Second, tc.width, 40)
+			model := newDashboardModel(metrics, nil, time.Second, nil, nil, 0, AggregationConfig{}, nil)
+			model.width = tc.width
 
 			cols, rows := model.calculateGrid()
 
@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/mcpherrinm/hrmm/internal/buffer"
+	"github.com/mcpherrinm/hrmm/internal/fetcher"
+)
+
+// histogramSumSuffix and histogramCountSuffix are the other two
+// companion series of a Prometheus histogram metric family; they're
+// folded into the single histogram selection entry rather than shown
+// as their own selectable rows.
+const (
+	histogramSumSuffix   = "_sum"
+	histogramCountSuffix = "_count"
+)
+
+// buildMetricItems converts scraped metrics into list.Item entries,
+// collapsing each histogram's `_bucket`/`_sum`/`_count` companion series
+// into a single selectable entry named after the base metric name.
+func buildMetricItems(allMetrics []fetcher.MetricData) []list.Item {
+	bounds := make(map[string]map[float64]bool)
+	histMeta := make(map[string]fetcher.MetricData)
+	var items []list.Item
+
+	for _, metric := range allMetrics {
+		if base, le, ok := histogramBucketName(metric.Name, metric.Labels); ok {
+			if bounds[base] == nil {
+				bounds[base] = make(map[float64]bool)
+				histMeta[base] = metric
+			}
+			bounds[base][le] = true
+		}
+	}
+
+	for _, metric := range allMetrics {
+		if _, _, ok := histogramBucketName(metric.Name, metric.Labels); ok {
+			continue
+		}
+		sumBase := strings.TrimSuffix(metric.Name, histogramSumSuffix)
+		countBase := strings.TrimSuffix(metric.Name, histogramCountSuffix)
+		if (sumBase != metric.Name && bounds[sumBase] != nil) || (countBase != metric.Name && bounds[countBase] != nil) {
+			// Dropped: represented by the collapsed histogram entry instead.
+			continue
+		}
+		items = append(items, metricItem{metric: metric, selected: false})
+	}
+
+	for base, set := range bounds {
+		upperBounds := make([]float64, 0, len(set))
+		for le := range set {
+			upperBounds = append(upperBounds, le)
+		}
+		sort.Float64s(upperBounds)
+		items = append(items, metricItem{
+			metric:      fetcher.MetricData{Name: base, Help: histMeta[base].Help},
+			isHistogram: true,
+			upperBounds: upperBounds,
+		})
+	}
+
+	return items
+}
+
+// histogramBoundsFromItems extracts the le-boundary slice for each
+// histogram entry among the list items, selected or not, keyed by base
+// metric name, for use by newDashboardModel.
+func histogramBoundsFromItems(items []list.Item) map[string][]float64 {
+	bounds := make(map[string][]float64)
+	for _, item := range items {
+		mi, ok := item.(metricItem)
+		if !ok || !mi.isHistogram {
+			continue
+		}
+		bounds[mi.metric.Name] = mi.upperBounds
+	}
+	return bounds
+}
+
+// histogramBucketSuffix is the Prometheus convention for the bucket
+// series of a histogram metric family: base_name_bucket{le="..."}.
+const histogramBucketSuffix = "_bucket"
+
+// histogramBucketName reports whether name is a `_bucket` series and,
+// if so, returns the histogram's base metric name and the numeric value
+// of its `le` boundary.
+func histogramBucketName(name string, labels map[string]string) (base string, le float64, ok bool) {
+	if !strings.HasSuffix(name, histogramBucketSuffix) {
+		return "", 0, false
+	}
+	leStr, present := labels["le"]
+	if !present {
+		return "", 0, false
+	}
+	le, err := strconv.ParseFloat(leStr, 64)
+	if err != nil {
+		return "", 0, false
+	}
+	return strings.TrimSuffix(name, histogramBucketSuffix), le, true
+}
+
+// histogramGraph holds the per-tick bucket snapshots for a single
+// histogram metric family, aligned to its `le` boundaries, and renders
+// them as a Braille heatmap instead of a single line.
+type histogramGraph struct {
+	name        string
+	upperBounds []float64 // le boundaries, ascending; last is +Inf
+	ticks       [][]float64
+	capacity    int
+	head        int
+	size        int
+}
+
+// newHistogramGraph creates a histogramGraph that retains up to
+// capacity ticks of bucket snapshots.
+func newHistogramGraph(name string, upperBounds []float64, capacity int) *histogramGraph {
+	return &histogramGraph{
+		name:        name,
+		upperBounds: upperBounds,
+		ticks:       make([][]float64, capacity),
+		capacity:    capacity,
+	}
+}
+
+// Push records one tick's cumulative bucket counts, aligned to
+// upperBounds, overwriting the oldest tick once at capacity.
+func (h *histogramGraph) Push(buckets []float64) {
+	h.ticks[h.head] = buckets
+	h.head = (h.head + 1) % h.capacity
+	if h.size < h.capacity {
+		h.size++
+	}
+}
+
+// Values returns the retained per-tick bucket snapshots in chronological
+// order (oldest first).
+func (h *histogramGraph) Values() [][]float64 {
+	if h.size == 0 {
+		return nil
+	}
+	result := make([][]float64, h.size)
+	start := (h.head - h.size + h.capacity) % h.capacity
+	for i := 0; i < h.size; i++ {
+		result[i] = h.ticks[(start+i)%h.capacity]
+	}
+	return result
+}
+
+// Percentile returns the qth percentile (0-100) of the most recently
+// pushed tick, or false if no ticks have been recorded yet.
+func (h *histogramGraph) Percentile(q float64) (float64, bool) {
+	if h.size == 0 {
+		return 0, false
+	}
+	idx := (h.head - 1 + h.capacity) % h.capacity
+	return buffer.PercentileFromBuckets(h.ticks[idx], h.upperBounds, q)
+}
+
+// brailleShades are Braille-derived glyphs of increasing visual weight,
+// used to shade a single heatmap cell by intensity.
+var brailleShades = []rune{' ', '⠄', '⠆', '⠖', '⠶', '⠷', '⠿', '⣿'}
+
+// shadeFor maps intensity (0..max) onto a brailleShades glyph.
+func shadeFor(intensity, max float64) rune {
+	if max <= 0 || intensity <= 0 {
+		return brailleShades[0]
+	}
+	level := int((intensity / max) * float64(len(brailleShades)-1))
+	if level >= len(brailleShades) {
+		level = len(brailleShades) - 1
+	}
+	if level < 0 {
+		level = 0
+	}
+	return brailleShades[level]
+}
+
+// renderHistogramCell renders a histogram's per-tick bucket deltas as a
+// Braille heatmap: columns are time (oldest on the left), rows are the
+// bucket boundaries, one row per le value in order (largest at the
+// top), so the vertical spacing is the histogram's own bucket layout
+// rather than a rescaled log axis, and a cell's shade
+// is the delta count observed in that bucket on that tick. The label
+// line also reports the current p50/p95/p99 via PercentileFromBuckets.
+func (m dashboardModel) renderHistogramCell(name string) string {
+	hg, ok := m.histograms[name]
+	if !ok {
+		return ""
+	}
+
+	ticks := hg.Values()
+	if len(ticks) < 2 {
+		return fmt.Sprintf("%s: (no data)", name)
+	}
+
+	maxDelta := 0.0
+	deltas := make([][]float64, len(ticks)-1)
+	for t := 1; t < len(ticks); t++ {
+		prev, cur := ticks[t-1], ticks[t]
+		row := make([]float64, len(cur))
+		for b := range cur {
+			d := cur[b] - prev[b]
+			if d < 0 {
+				d = 0
+			}
+			row[b] = d
+			if d > maxDelta {
+				maxDelta = d
+			}
+		}
+		deltas[t-1] = row
+	}
+
+	var sb strings.Builder
+	for bucket := len(hg.upperBounds) - 1; bucket >= 0; bucket-- {
+		for _, row := range deltas {
+			sb.WriteRune(shadeFor(row[bucket], maxDelta))
+		}
+		sb.WriteByte('\n')
+	}
+
+	p50, _ := hg.Percentile(50)
+	p95, _ := hg.Percentile(95)
+	p99, _ := hg.Percentile(99)
+	label := fmt.Sprintf("%s: p50=%.2f p95=%.2f p99=%.2f", name, p50, p95, p99)
+
+	return label + "\n" + sb.String()
+}
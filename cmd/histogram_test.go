@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mcpherrinm/hrmm/internal/fetcher"
+)
+
+func TestHistogramBucketName(t *testing.T) {
+	base, le, ok := histogramBucketName("request_duration_seconds_bucket", map[string]string{"le": "0.5"})
+	if !ok {
+		t.Fatal("expected ok=true for a bucket series with an le label")
+	}
+	if base != "request_duration_seconds" {
+		t.Errorf("expected base name request_duration_seconds, got %q", base)
+	}
+	if le != 0.5 {
+		t.Errorf("expected le=0.5, got %f", le)
+	}
+
+	if _, _, ok := histogramBucketName("request_duration_seconds_sum", nil); ok {
+		t.Error("expected ok=false for a non-bucket series")
+	}
+	if _, _, ok := histogramBucketName("request_duration_seconds_bucket", nil); ok {
+		t.Error("expected ok=false when the le label is missing")
+	}
+}
+
+func TestHistogramGraph_PushAndPercentile(t *testing.T) {
+	bounds := []float64{0.1, 0.5, 1, math.Inf(1)}
+	hg := newHistogramGraph("request_duration_seconds", bounds, 30)
+
+	if _, ok := hg.Percentile(50); ok {
+		t.Error("expected ok=false before any ticks are pushed")
+	}
+
+	hg.Push([]float64{2, 8, 10, 10})
+
+	p50, ok := hg.Percentile(50)
+	if !ok {
+		t.Fatal("expected ok=true after a push")
+	}
+	if math.Abs(p50-0.3) > 0.001 {
+		t.Errorf("expected p50~0.3, got %f", p50)
+	}
+}
+
+func TestHistogramGraph_ValuesChronological(t *testing.T) {
+	hg := newHistogramGraph("h", []float64{1, math.Inf(1)}, 2)
+	hg.Push([]float64{1, 1})
+	hg.Push([]float64{2, 2})
+	hg.Push([]float64{3, 3}) // overflows capacity of 2
+
+	values := hg.Values()
+	if len(values) != 2 {
+		t.Fatalf("expected 2 retained ticks, got %d", len(values))
+	}
+	if values[0][0] != 2 || values[1][0] != 3 {
+		t.Errorf("expected ticks [2,3] in order, got %v", values)
+	}
+}
+
+func TestBuildMetricItems_CollapsesHistogram(t *testing.T) {
+	allMetrics := []fetcher.MetricData{
+		{Name: "request_duration_seconds_bucket", Labels: map[string]string{"le": "0.1"}, Value: 2},
+		{Name: "request_duration_seconds_bucket", Labels: map[string]string{"le": "+Inf"}, Value: 10},
+		{Name: "request_duration_seconds_sum", Value: 4.2},
+		{Name: "request_duration_seconds_count", Value: 10},
+		{Name: "cpu_usage", Value: 0.5},
+	}
+
+	items := buildMetricItems(allMetrics)
+	if len(items) != 2 {
+		t.Fatalf("expected 2 items (1 histogram + 1 plain metric), got %d", len(items))
+	}
+
+	var sawHistogram, sawPlain bool
+	for _, item := range items {
+		mi := item.(metricItem)
+		switch {
+		case mi.isHistogram && mi.metric.Name == "request_duration_seconds":
+			sawHistogram = true
+			if len(mi.upperBounds) != 2 {
+				t.Errorf("expected 2 bucket boundaries, got %d", len(mi.upperBounds))
+			}
+		case !mi.isHistogram && mi.metric.Name == "cpu_usage":
+			sawPlain = true
+		}
+	}
+	if !sawHistogram {
+		t.Error("expected a collapsed histogram entry")
+	}
+	if !sawPlain {
+		t.Error("expected the non-histogram metric to remain its own entry")
+	}
+}
@@ -0,0 +1,56 @@
+// Package cmd implements hrmm's cobra commands.
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	urls            []string
+	metrics         []string
+	labels          []string
+	pollInterval    time.Duration
+	httpConfigFile  string
+	aliasFlags      []string
+	fetchTimeout    time.Duration
+	aggregateWindow time.Duration
+	aggregateFunc   string
+	aggregateGrace  time.Duration
+	aggregateDelay  time.Duration
+	streamURLs      []string
+)
+
+var rootCmd = &cobra.Command{
+	Use:   "hrmm",
+	Short: "hrmm polls Prometheus metrics endpoints for humans",
+	Long:  "hrmm (Human Readable Metrics Monitor) polls one or more Prometheus-compatible /metrics endpoints and renders them for humans, in a terminal graph or otherwise.",
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringSliceVar(&urls, "url", nil, "Prometheus metrics endpoint URL (repeatable)")
+	rootCmd.PersistentFlags().StringSliceVar(&metrics, "metrics", nil, "metric names to fetch (default: all)")
+	rootCmd.PersistentFlags().StringSliceVar(&labels, "labels", nil, "only fetch samples carrying one of these label names")
+	rootCmd.PersistentFlags().DurationVar(&pollInterval, "poll-interval", 5*time.Second, "how often to poll each endpoint")
+	rootCmd.PersistentFlags().StringVar(&httpConfigFile, "http.config.file", "", "YAML file with HTTP client config (TLS, basic auth, bearer token, proxy) for scraping secured endpoints")
+	rootCmd.PersistentFlags().StringSliceVar(&aliasFlags, "alias", nil, "display alias for a metric, as name=alias or endpoint|name=alias (repeatable)")
+	rootCmd.PersistentFlags().DurationVar(&fetchTimeout, "fetch-timeout", 10*time.Second, "maximum time a single poll may take per endpoint; capped at --poll-interval")
+	rootCmd.PersistentFlags().DurationVar(&aggregateWindow, "aggregate", 0, "downsample graphed metrics into one point per window of this duration (default: disabled, one point per poll)")
+	rootCmd.PersistentFlags().StringVar(&aggregateFunc, "aggregate-func", "avg", "how to collapse samples within an --aggregate window: avg, min, max, or last")
+	rootCmd.PersistentFlags().DurationVar(&aggregateGrace, "aggregate-grace", 0, "tolerance for a sample landing slightly before the current --aggregate window's start before it's dropped instead of rotating the window")
+	rootCmd.PersistentFlags().DurationVar(&aggregateDelay, "aggregate-delay", 0, "tolerance for a sample landing slightly after the current --aggregate window's end before the window rotates instead of absorbing it")
+	rootCmd.PersistentFlags().StringSliceVar(&streamURLs, "stream-url", nil, "SSE metrics endpoint to push-subscribe to instead of polling (repeatable); metrics must also be discoverable via --url for the picker")
+
+	rootCmd.AddCommand(graphCmd)
+}
+
+// Execute runs the root command, exiting the process on error.
+func Execute() {
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,129 @@
+package buffer
+
+import "time"
+
+// AggregateFunc selects how the samples collected within one
+// aggregation window are collapsed into a single point.
+type AggregateFunc string
+
+const (
+	AggregateAvg  AggregateFunc = "avg"
+	AggregateMin  AggregateFunc = "min"
+	AggregateMax  AggregateFunc = "max"
+	AggregateLast AggregateFunc = "last"
+)
+
+// aggBucket accumulates the samples seen within one aggregation window.
+type aggBucket struct {
+	start time.Time
+	count int
+	sum   float64
+	min   float64
+	max   float64
+	last  float64
+}
+
+func (b *aggBucket) add(value float64) {
+	if b.count == 0 {
+		b.min, b.max = value, value
+	}
+	if value < b.min {
+		b.min = value
+	}
+	if value > b.max {
+		b.max = value
+	}
+	b.sum += value
+	b.last = value
+	b.count++
+}
+
+func (b *aggBucket) value(fn AggregateFunc) float64 {
+	switch fn {
+	case AggregateMin:
+		return b.min
+	case AggregateMax:
+		return b.max
+	case AggregateLast:
+		return b.last
+	default:
+		return b.sum / float64(b.count)
+	}
+}
+
+// Aggregator downsamples a stream of timestamped samples into fixed
+// windows, invoking onFlush with one AggregateFunc-collapsed point per
+// window. It sits between a fetcher and a metric's RingBuffer so a
+// long-running dashboard keeps a representative view of the whole
+// session instead of the buffer's fixed capacity wrapping raw per-tick
+// samples.
+//
+// Windows are delimited by the timestamp of the first sample that
+// starts them, not a fixed grid: periodStart is that sample's time,
+// periodEnd is periodStart+window. grace extends how far before
+// periodStart a sample may still land before it's dropped outright
+// (and counted in Dropped) rather than rotating the window; delay
+// extends how far after periodEnd a sample may still land before it
+// rotates the window, absorbing it into the current one instead.
+// Samples never drop for arriving late, only for arriving too early -
+// forward progress in time always keeps the aggregator moving.
+type Aggregator struct {
+	window  time.Duration
+	fn      AggregateFunc
+	grace   time.Duration
+	delay   time.Duration
+	onFlush func(t time.Time, value float64)
+
+	pending     *aggBucket
+	periodStart time.Time
+	periodEnd   time.Time
+
+	Dropped int
+}
+
+// NewAggregator builds an Aggregator that flushes one aggregated point
+// per window of the given size, via onFlush.
+func NewAggregator(window time.Duration, fn AggregateFunc, grace, delay time.Duration, onFlush func(t time.Time, value float64)) *Aggregator {
+	return &Aggregator{window: window, fn: fn, grace: grace, delay: delay, onFlush: onFlush}
+}
+
+// Push adds a sample at time t to the current window. If t falls
+// before periodStart by more than grace, the sample is dropped and
+// counted in Dropped. If t falls after periodEnd but within delay, it
+// is absorbed into the still-open window rather than rotating it for
+// every minor bit of per-tick jitter. Otherwise, if t falls outside
+// [periodStart, periodEnd), the current window is flushed and a new
+// one is started at t.
+func (a *Aggregator) Push(t time.Time, value float64) {
+	if a.pending == nil {
+		a.startWindow(t)
+		a.pending.add(value)
+		return
+	}
+
+	switch {
+	case t.Before(a.periodStart.Add(-a.grace)):
+		a.Dropped++
+	case t.After(a.periodEnd) && !t.After(a.periodEnd.Add(a.delay)):
+		a.pending.add(value)
+	case t.Before(a.periodStart) || !t.Before(a.periodEnd):
+		a.flush()
+		a.startWindow(t)
+		a.pending.add(value)
+	default:
+		a.pending.add(value)
+	}
+}
+
+func (a *Aggregator) startWindow(t time.Time) {
+	a.pending = &aggBucket{start: t}
+	a.periodStart = t
+	a.periodEnd = t.Add(a.window)
+}
+
+func (a *Aggregator) flush() {
+	if a.pending == nil || a.pending.count == 0 {
+		return
+	}
+	a.onFlush(a.pending.start, a.pending.value(a.fn))
+}
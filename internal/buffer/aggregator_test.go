@@ -0,0 +1,133 @@
+package buffer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAggregator_OnePointPerWindow(t *testing.T) {
+	var flushed []float64
+	agg := NewAggregator(10*time.Second, AggregateAvg, 0, 0, func(_ time.Time, v float64) {
+		flushed = append(flushed, v)
+	})
+
+	base := time.Unix(0, 0)
+	// 5 samples in window 1 ([0s, 10s)), 5 in window 2 ([11s, 21s)).
+	for i := 0; i < 5; i++ {
+		agg.Push(base.Add(time.Duration(i)*time.Second), float64(i+1)) // 1..5, avg 3
+	}
+	for i := 0; i < 5; i++ {
+		agg.Push(base.Add(11*time.Second+time.Duration(i)*time.Second), float64(10*(i+1))) // 10..50, avg 30
+	}
+
+	if len(flushed) != 1 {
+		t.Fatalf("expected exactly 1 flushed point (the first window), got %d: %v", len(flushed), flushed)
+	}
+	if flushed[0] != 3 {
+		t.Errorf("expected window 1's average to be 3, got %v", flushed[0])
+	}
+}
+
+func TestAggregator_ExactBoundarySampleRotatesWindow(t *testing.T) {
+	var flushed []float64
+	agg := NewAggregator(10*time.Second, AggregateAvg, 0, 0, func(_ time.Time, v float64) {
+		flushed = append(flushed, v)
+	})
+
+	base := time.Unix(0, 0)
+	agg.Push(base, 1)
+	agg.Push(base.Add(5*time.Second), 3) // still in [0,10), avg so far (1+3)/2=2
+
+	// Lands exactly on periodEnd (base+10s): half-open window excludes it,
+	// so this must flush window 1 and start window 2, not merge into it.
+	agg.Push(base.Add(10*time.Second), 100)
+
+	if len(flushed) != 1 {
+		t.Fatalf("expected the boundary sample to flush exactly 1 prior window, got %d: %v", len(flushed), flushed)
+	}
+	if flushed[0] != 2 {
+		t.Errorf("expected window 1's average to be 2, got %v", flushed[0])
+	}
+	if agg.Dropped != 0 {
+		t.Errorf("expected the boundary sample to rotate the window, not be dropped, got Dropped=%d", agg.Dropped)
+	}
+}
+
+func TestAggregator_ClockJumpBackwardIsDropped(t *testing.T) {
+	var flushed []float64
+	agg := NewAggregator(10*time.Second, AggregateAvg, time.Second, 0, func(_ time.Time, v float64) {
+		flushed = append(flushed, v)
+	})
+
+	base := time.Unix(1000, 0)
+	agg.Push(base, 1)
+	agg.Push(base.Add(2*time.Second), 3)
+
+	// A sample that arrives claiming a time long before periodStart -
+	// grace (e.g. a stale retry, or the system clock stepping backward)
+	// must not panic or corrupt the current window.
+	agg.Push(base.Add(-time.Hour), 999)
+
+	if agg.Dropped != 1 {
+		t.Errorf("expected the wildly out-of-order sample to be dropped, Dropped=%d", agg.Dropped)
+	}
+	if len(flushed) != 0 {
+		t.Errorf("expected no flush yet (window 1 still open), got %v", flushed)
+	}
+
+	// The current window must still be intact and accept further
+	// in-order samples.
+	agg.Push(base.Add(3*time.Second), 5)
+	agg.Push(base.Add(10*time.Second), 0) // exactly periodEnd: rotates window 1 out
+	if len(flushed) != 1 {
+		t.Fatalf("expected window 1 to flush once the real clock advanced past it, got %v", flushed)
+	}
+	if flushed[0] != 3 { // avg(1,3,5) = 3
+		t.Errorf("expected window 1's average to be 3, got %v", flushed[0])
+	}
+}
+
+func TestAggregator_GraceAcceptsSlightlyEarlySample(t *testing.T) {
+	var flushed []float64
+	agg := NewAggregator(10*time.Second, AggregateAvg, 2*time.Second, 0, func(_ time.Time, v float64) {
+		flushed = append(flushed, v)
+	})
+
+	base := time.Unix(0, 0)
+	agg.Push(base, 10)
+	// 1 second before periodStart, within the 2s grace tolerance: since
+	// it's still before periodStart, it rotates the window rather than
+	// merging, but it must not be dropped.
+	agg.Push(base.Add(-time.Second), 20)
+
+	if agg.Dropped != 0 {
+		t.Errorf("expected the slightly-early sample to be tolerated, got Dropped=%d", agg.Dropped)
+	}
+	if len(flushed) != 1 || flushed[0] != 10 {
+		t.Errorf("expected window 1 (just the first sample) to flush with value 10, got %v", flushed)
+	}
+}
+
+func TestAggregator_MinMaxLast(t *testing.T) {
+	base := time.Unix(0, 0)
+	for _, tc := range []struct {
+		fn   AggregateFunc
+		want float64
+	}{
+		{AggregateMin, 1},
+		{AggregateMax, 9},
+		{AggregateLast, 4},
+	} {
+		var flushed float64
+		agg := NewAggregator(10*time.Second, tc.fn, 0, 0, func(_ time.Time, v float64) { flushed = v })
+		agg.Push(base, 5)
+		agg.Push(base.Add(time.Second), 1)
+		agg.Push(base.Add(2*time.Second), 9)
+		agg.Push(base.Add(3*time.Second), 4)
+		agg.Push(base.Add(10*time.Second), 0) // exactly periodEnd: rotate, forcing the flush
+
+		if flushed != tc.want {
+			t.Errorf("%s: expected %v, got %v", tc.fn, tc.want, flushed)
+		}
+	}
+}
@@ -1,9 +1,16 @@
 package buffer
 
-// RingBuffer stores a fixed number of float64 values in FIFO order.
-// When capacity is reached, oldest values are overwritten.
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// RingBuffer stores a fixed number of (time, value) samples in FIFO
+// order. When capacity is reached, the oldest sample is overwritten.
 type RingBuffer struct {
 	data     []float64
+	times    []time.Time
 	capacity int
 	head     int // next write position
 	size     int // current number of elements
@@ -13,13 +20,25 @@ type RingBuffer struct {
 func New(capacity int) *RingBuffer {
 	return &RingBuffer{
 		data:     make([]float64, capacity),
+		times:    make([]time.Time, capacity),
 		capacity: capacity,
 	}
 }
 
-// Push adds a value to the buffer, overwriting the oldest if at capacity.
+// Push adds a value to the buffer, timestamped with time.Now(),
+// overwriting the oldest sample if at capacity.
 func (rb *RingBuffer) Push(value float64) {
+	rb.PushAt(time.Now(), value)
+}
+
+// PushAt adds a value to the buffer with an explicit timestamp,
+// overwriting the oldest sample if at capacity. Callers that know the
+// real sample time (e.g. when a scrape was delayed) should prefer this
+// over Push so Rate/Irate reflect actual elapsed time rather
+// than assuming a fixed poll cadence.
+func (rb *RingBuffer) PushAt(t time.Time, value float64) {
 	rb.data[rb.head] = value
+	rb.times[rb.head] = t
 	rb.head = (rb.head + 1) % rb.capacity
 	if rb.size < rb.capacity {
 		rb.size++
@@ -40,6 +59,20 @@ func (rb *RingBuffer) Values() []float64 {
 	return result
 }
 
+// Times returns the timestamp of each value returned by Values, in the
+// same chronological order. Returns nil if the buffer is empty.
+func (rb *RingBuffer) Times() []time.Time {
+	if rb.size == 0 {
+		return nil
+	}
+	result := make([]time.Time, rb.size)
+	start := (rb.head - rb.size + rb.capacity) % rb.capacity
+	for i := 0; i < rb.size; i++ {
+		result[i] = rb.times[(start+i)%rb.capacity]
+	}
+	return result
+}
+
 // Len returns the current number of elements in the buffer.
 func (rb *RingBuffer) Len() int {
 	return rb.size
@@ -145,3 +178,200 @@ func (rb *RingBuffer) Trend() int {
 	}
 	return 0 // flat
 }
+
+// StdDev returns the population standard deviation of all values in
+// the buffer, or 0 and false if empty.
+func (rb *RingBuffer) StdDev() (float64, bool) {
+	if rb.size == 0 {
+		return 0, false
+	}
+	values := rb.Values()
+	avg, _ := rb.Avg()
+
+	sumSquares := 0.0
+	for _, v := range values {
+		d := v - avg
+		sumSquares += d * d
+	}
+	return math.Sqrt(sumSquares / float64(len(values))), true
+}
+
+// CV returns the coefficient of variation (StdDev/Avg) of all values
+// in the buffer, or 0 and false if empty or the mean is zero.
+func (rb *RingBuffer) CV() (float64, bool) {
+	avg, ok := rb.Avg()
+	if !ok || avg == 0 {
+		return 0, false
+	}
+	stddev, _ := rb.StdDev()
+	return stddev / avg, true
+}
+
+// Percentile returns the qth percentile (0-100) of all values in the
+// buffer using linear interpolation between the two nearest ranks, or
+// 0 and false if empty or q is out of range.
+func (rb *RingBuffer) Percentile(q float64) (float64, bool) {
+	if rb.size == 0 || q < 0 || q > 100 {
+		return 0, false
+	}
+	values := rb.Values()
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	if len(sorted) == 1 {
+		return sorted[0], true
+	}
+
+	idx := (q / 100) * float64(len(sorted)-1)
+	lo := int(math.Floor(idx))
+	hi := int(math.Ceil(idx))
+	if lo == hi {
+		return sorted[lo], true
+	}
+	frac := idx - float64(lo)
+	return sorted[lo]*(1-frac) + sorted[hi]*frac, true
+}
+
+// Median returns the 50th percentile of all values in the buffer, or 0
+// and false if empty.
+func (rb *RingBuffer) Median() (float64, bool) {
+	return rb.Percentile(50)
+}
+
+// Rate returns the per-second rate of change across all samples in the
+// buffer, computed via least-squares linear regression against each
+// sample's real timestamp (set by PushAt, or time.Now() via Push).
+// Unlike assuming a fixed poll cadence, this stays correct when a fetch
+// was delayed or a scrape was missed. Returns 0 and false if there are
+// fewer than 2 samples or they all share one timestamp.
+func (rb *RingBuffer) Rate() (float64, bool) {
+	if rb.size < 2 {
+		return 0, false
+	}
+	values := rb.Values()
+	times := rb.Times()
+
+	t0 := times[0]
+	n := float64(len(values))
+	var sumX, sumY, sumXY, sumXX float64
+	for i, v := range values {
+		x := times[i].Sub(t0).Seconds()
+		sumX += x
+		sumY += v
+		sumXY += x * v
+		sumXX += x * x
+	}
+	denom := n*sumXX - sumX*sumX
+	if denom == 0 {
+		return 0, false
+	}
+	return (n*sumXY - sumX*sumY) / denom, true
+}
+
+// Irate returns the per-second rate of change between the two most
+// recent samples, matching PromQL's irate() which favors instantaneous
+// accuracy over Rate's smoothing across the whole window. Returns 0 and
+// false if there are fewer than 2 samples or they share one timestamp.
+func (rb *RingBuffer) Irate() (float64, bool) {
+	if rb.size < 2 {
+		return 0, false
+	}
+	values := rb.Values()
+	times := rb.Times()
+	n := len(values)
+
+	dt := times[n-1].Sub(times[n-2]).Seconds()
+	if dt <= 0 {
+		return 0, false
+	}
+	return (values[n-1] - values[n-2]) / dt, true
+}
+
+// Delta returns the difference between the newest and oldest sample in
+// the buffer, matching PromQL's delta() for gauges: unlike Increase, a
+// decrease is a valid (negative) delta, not a counter reset. Returns 0
+// and false if there are fewer than 2 samples.
+func (rb *RingBuffer) Delta() (float64, bool) {
+	if rb.size < 2 {
+		return 0, false
+	}
+	values := rb.Values()
+	return values[len(values)-1] - values[0], true
+}
+
+// Increase returns the total increase of a counter across all samples
+// in the buffer, matching PromQL's increase(). A decrease between
+// consecutive samples is treated as a counter reset (e.g. the process
+// restarted): the post-reset value is counted as the increase for that
+// step rather than producing a negative delta. Returns 0 and false if
+// there are fewer than 2 samples.
+func (rb *RingBuffer) Increase() (float64, bool) {
+	if rb.size < 2 {
+		return 0, false
+	}
+	values := rb.Values()
+
+	total := 0.0
+	for i := 1; i < len(values); i++ {
+		diff := values[i] - values[i-1]
+		if diff < 0 {
+			total += values[i]
+		} else {
+			total += diff
+		}
+	}
+	return total, true
+}
+
+// Resets returns the number of counter resets (a sample lower than the
+// one before it) observed across all samples in the buffer.
+func (rb *RingBuffer) Resets() int {
+	values := rb.Values()
+	resets := 0
+	for i := 1; i < len(values); i++ {
+		if values[i] < values[i-1] {
+			resets++
+		}
+	}
+	return resets
+}
+
+// PercentileFromBuckets estimates the qth percentile (0-100) from a
+// cumulative Prometheus-style histogram, using the same linear
+// interpolation as PromQL's histogram_quantile: within the bucket that
+// contains the target rank, the boundary is interpolated proportionally
+// to how far the rank falls between the bucket's lower and upper edges.
+// buckets holds the cumulative sample count for each of upperBounds,
+// which must be sorted ascending and the same length as buckets; the
+// final upperBound is conventionally +Inf. Returns false if there's no
+// data to estimate from or q is out of range.
+func PercentileFromBuckets(buckets []float64, upperBounds []float64, q float64) (float64, bool) {
+	if len(buckets) == 0 || len(buckets) != len(upperBounds) || q < 0 || q > 100 {
+		return 0, false
+	}
+	total := buckets[len(buckets)-1]
+	if total <= 0 {
+		return 0, false
+	}
+	rank := (q / 100) * total
+
+	var prevCount, prevBound float64
+	for i, count := range buckets {
+		if count >= rank {
+			bound := upperBounds[i]
+			if math.IsInf(bound, 1) {
+				// The +Inf bucket has no real upper edge to interpolate
+				// against, so cap the estimate at the previous boundary.
+				return prevBound, true
+			}
+			if count == prevCount {
+				return bound, true
+			}
+			frac := (rank - prevCount) / (count - prevCount)
+			return prevBound + frac*(bound-prevBound), true
+		}
+		prevCount = count
+		prevBound = upperBounds[i]
+	}
+	return prevBound, true
+}
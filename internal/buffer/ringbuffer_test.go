@@ -423,26 +423,27 @@ func TestRingBuffer_Median(t *testing.T) {
 }
 
 func TestRingBuffer_Rate(t *testing.T) {
+	base := time.Unix(0, 0)
+
 	rb := New(30)
-	interval := time.Second
 
 	// Empty buffer
-	_, ok := rb.Rate(interval)
+	_, ok := rb.Rate()
 	if ok {
 		t.Error("expected ok=false for empty buffer")
 	}
 
 	// Single value - need at least 2
-	rb.Push(10.0)
-	_, ok = rb.Rate(interval)
+	rb.PushAt(base, 10.0)
+	_, ok = rb.Rate()
 	if ok {
 		t.Error("expected ok=false for single value")
 	}
 
-	// Two values: 10, 20 with 1 second interval
+	// Two values: 10, 20 one second apart
 	// Rate = (20-10) / 1 = 10 per second
-	rb.Push(20.0)
-	rate, ok := rb.Rate(interval)
+	rb.PushAt(base.Add(time.Second), 20.0)
+	rate, ok := rb.Rate()
 	if !ok {
 		t.Error("expected ok=true")
 	}
@@ -450,13 +451,13 @@ func TestRingBuffer_Rate(t *testing.T) {
 		t.Errorf("expected rate=10.0, got %f", rate)
 	}
 
-	// Five values: 0, 10, 20, 30, 40 with 1 second interval
+	// Five values: 0, 10, 20, 30, 40, one second apart
 	// Rate = (40-0) / 4 = 10 per second
 	rb = New(30)
 	for i := 0; i <= 4; i++ {
-		rb.Push(float64(i * 10))
+		rb.PushAt(base.Add(time.Duration(i)*time.Second), float64(i*10))
 	}
-	rate, ok = rb.Rate(interval)
+	rate, ok = rb.Rate()
 	if !ok {
 		t.Error("expected ok=true")
 	}
@@ -464,9 +465,13 @@ func TestRingBuffer_Rate(t *testing.T) {
 		t.Errorf("expected rate=10.0, got %f", rate)
 	}
 
-	// Same values with 2 second interval
+	// Same values spaced 2 seconds apart
 	// Rate = (40-0) / 8 = 5 per second
-	rate, ok = rb.Rate(2 * time.Second)
+	rb = New(30)
+	for i := 0; i <= 4; i++ {
+		rb.PushAt(base.Add(time.Duration(i)*2*time.Second), float64(i*10))
+	}
+	rate, ok = rb.Rate()
 	if !ok {
 		t.Error("expected ok=true")
 	}
@@ -474,10 +479,170 @@ func TestRingBuffer_Rate(t *testing.T) {
 		t.Errorf("expected rate=5.0, got %f", rate)
 	}
 
-	// Zero interval should return false
-	_, ok = rb.Rate(0)
-	if ok {
-		t.Error("expected ok=false for zero interval")
+	// All samples sharing one timestamp should return false
+	rb = New(30)
+	rb.PushAt(base, 1.0)
+	rb.PushAt(base, 2.0)
+	if _, ok := rb.Rate(); ok {
+		t.Error("expected ok=false when all timestamps are identical")
+	}
+}
+
+func TestRingBuffer_Irate(t *testing.T) {
+	base := time.Unix(0, 0)
+	rb := New(30)
+
+	if _, ok := rb.Irate(); ok {
+		t.Error("expected ok=false for empty buffer")
+	}
+
+	rb.PushAt(base, 10.0)
+	if _, ok := rb.Irate(); ok {
+		t.Error("expected ok=false for single value")
+	}
+
+	// Irate only looks at the last two samples, unlike Rate's regression
+	// over the whole window.
+	rb.PushAt(base.Add(time.Second), 20.0)
+	rb.PushAt(base.Add(5*time.Second), 24.0)
+	rate, ok := rb.Irate()
+	if !ok {
+		t.Fatal("expected ok=true")
+	}
+	if math.Abs(rate-1.0) > 0.001 {
+		t.Errorf("expected irate=1.0 ((24-20)/4s), got %f", rate)
+	}
+}
+
+func TestRingBuffer_Delta(t *testing.T) {
+	rb := New(30)
+	if _, ok := rb.Delta(); ok {
+		t.Error("expected ok=false for empty buffer")
+	}
+
+	rb.Push(10.0)
+	if _, ok := rb.Delta(); ok {
+		t.Error("expected ok=false for single value")
+	}
+
+	rb.Push(4.0) // a decrease is a valid delta for gauges
+	delta, ok := rb.Delta()
+	if !ok {
+		t.Fatal("expected ok=true")
+	}
+	if delta != -6.0 {
+		t.Errorf("expected delta=-6.0, got %f", delta)
+	}
+}
+
+func TestRingBuffer_Increase(t *testing.T) {
+	rb := New(30)
+	if _, ok := rb.Increase(); ok {
+		t.Error("expected ok=false for empty buffer")
+	}
+
+	// Monotonic counter: 10, 20, 35 -> increase of 25
+	rb.Push(10.0)
+	rb.Push(20.0)
+	rb.Push(35.0)
+	increase, ok := rb.Increase()
+	if !ok {
+		t.Fatal("expected ok=true")
+	}
+	if increase != 25.0 {
+		t.Errorf("expected increase=25.0, got %f", increase)
+	}
+
+	// Counter reset: 10, 20, 5 (reset), 15 -> increase of 10 + 5 + 10 = 25
+	rb = New(30)
+	rb.Push(10.0)
+	rb.Push(20.0)
+	rb.Push(5.0)
+	rb.Push(15.0)
+	increase, ok = rb.Increase()
+	if !ok {
+		t.Fatal("expected ok=true")
+	}
+	if increase != 25.0 {
+		t.Errorf("expected increase=25.0 across a reset, got %f", increase)
+	}
+}
+
+func TestRingBuffer_Resets(t *testing.T) {
+	rb := New(30)
+	if rb.Resets() != 0 {
+		t.Errorf("expected 0 resets for empty buffer, got %d", rb.Resets())
+	}
+
+	rb.Push(10.0)
+	rb.Push(20.0)
+	rb.Push(5.0) // reset
+	rb.Push(15.0)
+	rb.Push(3.0) // reset
+	if rb.Resets() != 2 {
+		t.Errorf("expected 2 resets, got %d", rb.Resets())
+	}
+}
+
+func TestRingBuffer_PushAtOverwritesTimestamps(t *testing.T) {
+	rb := New(2)
+	base := time.Unix(100, 0)
+	rb.PushAt(base, 1.0)
+	rb.PushAt(base.Add(time.Second), 2.0)
+	rb.PushAt(base.Add(2*time.Second), 3.0) // overwrites the oldest
+
+	times := rb.Times()
+	if len(times) != 2 {
+		t.Fatalf("expected 2 timestamps, got %d", len(times))
+	}
+	if !times[0].Equal(base.Add(time.Second)) || !times[1].Equal(base.Add(2*time.Second)) {
+		t.Errorf("expected timestamps to follow the overwritten values, got %v", times)
+	}
+}
+
+func TestPercentileFromBuckets(t *testing.T) {
+	// Boundaries: 0.1, 0.5, 1, +Inf with cumulative counts 2, 8, 10, 10.
+	upperBounds := []float64{0.1, 0.5, 1, math.Inf(1)}
+	buckets := []float64{2, 8, 10, 10}
+
+	p50, ok := PercentileFromBuckets(buckets, upperBounds, 50)
+	if !ok {
+		t.Fatal("expected ok=true")
+	}
+	// rank = 5, falls in the (0.1, 0.5] bucket with counts (2, 8):
+	// 0.1 + (5-2)/(8-2) * (0.5-0.1) = 0.3
+	if math.Abs(p50-0.3) > 0.001 {
+		t.Errorf("expected p50~0.3, got %f", p50)
+	}
+
+	p100, ok := PercentileFromBuckets(buckets, upperBounds, 100)
+	if !ok {
+		t.Fatal("expected ok=true")
+	}
+	// rank = 10 is already reached by the (0.5, 1] bucket with counts
+	// (8, 10): 0.5 + (10-8)/(10-8) * (1-0.5) = 1, the bucket's upper edge.
+	if p100 != 1 {
+		t.Errorf("expected p100=1 (upper edge of the (0.5, 1] bucket), got %f", p100)
+	}
+
+	// Empty buckets.
+	if _, ok := PercentileFromBuckets(nil, nil, 50); ok {
+		t.Error("expected ok=false for empty buckets")
+	}
+
+	// Mismatched lengths.
+	if _, ok := PercentileFromBuckets([]float64{1}, []float64{1, 2}, 50); ok {
+		t.Error("expected ok=false for mismatched lengths")
+	}
+
+	// No samples yet.
+	if _, ok := PercentileFromBuckets([]float64{0, 0}, []float64{1, math.Inf(1)}, 50); ok {
+		t.Error("expected ok=false when total count is zero")
+	}
+
+	// Out of range quantile.
+	if _, ok := PercentileFromBuckets(buckets, upperBounds, 101); ok {
+		t.Error("expected ok=false for q > 100")
 	}
 }
 
@@ -0,0 +1,176 @@
+package buffer
+
+import (
+	"math"
+	"sort"
+)
+
+// SummaryTarget is one quantile a Summary is tuned to estimate
+// accurately, with Epsilon the allowed rank error around it (e.g.
+// {0.95, 0.005} means the 95th percentile is accurate to within 0.5%
+// of the observation count either side).
+type SummaryTarget struct {
+	Quantile float64
+	Epsilon  float64
+}
+
+// DefaultSummaryTargets are the quantiles tracked by NewSummary: p50,
+// p90, p95, and p99, with tighter error bounds at the tail where
+// precision matters most.
+var DefaultSummaryTargets = []SummaryTarget{
+	{Quantile: 0.5, Epsilon: 0.05},
+	{Quantile: 0.9, Epsilon: 0.01},
+	{Quantile: 0.95, Epsilon: 0.005},
+	{Quantile: 0.99, Epsilon: 0.001},
+}
+
+// summarySample is one tuple of the CKMS summary: v is the observed
+// value, g is the difference in rank from the previous tuple, and delta
+// is the maximum rank uncertainty for v.
+type summarySample struct {
+	v     float64
+	g     int
+	delta int
+}
+
+// Summary is a streaming biased quantile sketch (Cormode, Korn,
+// Muthukrishnan, Srivastava, "Effective Computation of Biased Quantiles
+// over Data Streams"). Unlike RingBuffer.Percentile, which sorts a copy
+// of the last 30 samples, Summary maintains approximate quantiles over
+// an unbounded stream in sublinear space, making it suitable for
+// "session" percentiles spanning hours of polling instead of just the
+// visible window. It is not safe for concurrent use.
+type Summary struct {
+	targets          []SummaryTarget
+	samples          []summarySample
+	n                int
+	insertsSinceSort int
+}
+
+// compressEvery controls how often Insert triggers a compression pass;
+// compressing on every insert would be correct but needlessly slow.
+const compressEvery = 64
+
+// NewSummary creates a Summary tracking DefaultSummaryTargets.
+func NewSummary() *Summary {
+	return NewSummaryWithTargets(DefaultSummaryTargets)
+}
+
+// NewSummaryWithTargets creates a Summary tracking the given
+// (quantile, epsilon) pairs instead of DefaultSummaryTargets.
+func NewSummaryWithTargets(targets []SummaryTarget) *Summary {
+	return &Summary{targets: append([]SummaryTarget(nil), targets...)}
+}
+
+// Insert adds a value to the stream, inserting it in rank order with a
+// delta bound derived from the configured targets, then periodically
+// compresses neighboring tuples that no longer need to be distinguished.
+func (s *Summary) Insert(v float64) {
+	i := sort.Search(len(s.samples), func(i int) bool { return s.samples[i].v >= v })
+
+	var delta int
+	if i > 0 && i < len(s.samples) {
+		rank := s.rankAt(i)
+		delta = int(math.Floor(s.errorBound(rank, s.n)))
+		if delta < 0 {
+			delta = 0
+		}
+	}
+
+	s.samples = append(s.samples, summarySample{})
+	copy(s.samples[i+1:], s.samples[i:])
+	s.samples[i] = summarySample{v: v, g: 1, delta: delta}
+	s.n++
+
+	s.insertsSinceSort++
+	if s.insertsSinceSort >= compressEvery {
+		s.compress()
+		s.insertsSinceSort = 0
+	}
+}
+
+// rankAt returns the rank (count of samples strictly before i, plus 1)
+// implied by the cumulative g values up to and including index i.
+func (s *Summary) rankAt(i int) int {
+	rank := 0
+	for j := 0; j <= i && j < len(s.samples); j++ {
+		rank += s.samples[j].g
+	}
+	return rank
+}
+
+// errorBound is f(rank, n): the minimum, over all configured targets,
+// of the allowed rank uncertainty at the given rank in a stream of n
+// observations so far.
+func (s *Summary) errorBound(rank, n int) float64 {
+	if n == 0 {
+		return 0
+	}
+	best := math.Inf(1)
+	r, total := float64(rank), float64(n)
+	for _, t := range s.targets {
+		var f float64
+		if r <= t.Quantile*total {
+			f = 2 * t.Epsilon * r / t.Quantile
+		} else {
+			f = 2 * t.Epsilon * (total - r) / (1 - t.Quantile)
+		}
+		if f < best {
+			best = f
+		}
+	}
+	return best
+}
+
+// compress merges adjacent tuples whose combined uncertainty still
+// fits within the error bound at their rank, shrinking the sketch back
+// toward O(1/eps * log(eps*n)) tuples.
+func (s *Summary) compress() {
+	if len(s.samples) < 2 {
+		return
+	}
+	rank := s.samples[0].g
+	for i := 1; i < len(s.samples)-1; {
+		next := s.samples[i+1]
+		merged := s.samples[i].g + next.g
+		if merged+next.delta <= int(s.errorBound(rank+merged, s.n)) {
+			s.samples[i+1].g = merged
+			s.samples = append(s.samples[:i], s.samples[i+1:]...)
+			continue
+		}
+		rank += s.samples[i].g
+		i++
+	}
+}
+
+// Query returns an approximation of the qth quantile (0..1), or false
+// if no values have been inserted yet.
+func (s *Summary) Query(q float64) (float64, bool) {
+	if len(s.samples) == 0 {
+		return 0, false
+	}
+	if q <= 0 {
+		return s.samples[0].v, true
+	}
+	if q >= 1 {
+		return s.samples[len(s.samples)-1].v, true
+	}
+
+	target := q * float64(s.n)
+	rank := 0
+	for _, sam := range s.samples {
+		rank += sam.g
+		// A tuple satisfies the query once its rank (plus its own
+		// uncertainty) has reached the target rank within the allowed
+		// error bound at that point in the stream.
+		if float64(rank)+float64(sam.delta) > target+s.errorBound(rank, s.n) {
+			return sam.v, true
+		}
+	}
+	return s.samples[len(s.samples)-1].v, true
+}
+
+// Count returns the number of values inserted so far.
+func (s *Summary) Count() int {
+	return s.n
+}
@@ -0,0 +1,75 @@
+package buffer
+
+import "testing"
+
+func TestSummary_EmptyReturnsFalse(t *testing.T) {
+	s := NewSummary()
+	if _, ok := s.Query(0.5); ok {
+		t.Error("expected ok=false for empty summary")
+	}
+}
+
+func TestSummary_SingleValue(t *testing.T) {
+	s := NewSummary()
+	s.Insert(42.0)
+
+	for _, q := range []float64{0, 0.5, 0.99, 1} {
+		v, ok := s.Query(q)
+		if !ok {
+			t.Fatalf("expected ok=true for q=%f", q)
+		}
+		if v != 42.0 {
+			t.Errorf("expected 42.0 for q=%f, got %f", q, v)
+		}
+	}
+}
+
+func TestSummary_ApproximatesPercentiles(t *testing.T) {
+	s := NewSummary()
+	for i := 1; i <= 1000; i++ {
+		s.Insert(float64(i))
+	}
+
+	if s.Count() != 1000 {
+		t.Fatalf("expected count 1000, got %d", s.Count())
+	}
+
+	p50, ok := s.Query(0.5)
+	if !ok {
+		t.Fatal("expected ok=true")
+	}
+	// Allow generous tolerance: this is an approximate sketch, not an
+	// exact percentile.
+	if p50 < 400 || p50 > 600 {
+		t.Errorf("expected p50 roughly near 500, got %f", p50)
+	}
+
+	p99, ok := s.Query(0.99)
+	if !ok {
+		t.Fatal("expected ok=true")
+	}
+	if p99 < 950 || p99 > 1000 {
+		t.Errorf("expected p99 roughly near 990, got %f", p99)
+	}
+
+	p0, _ := s.Query(0)
+	if p0 != 1 {
+		t.Errorf("expected min value 1, got %f", p0)
+	}
+	p100, _ := s.Query(1)
+	if p100 != 1000 {
+		t.Errorf("expected max value 1000, got %f", p100)
+	}
+}
+
+func TestSummary_UnboundedByCapacity(t *testing.T) {
+	// Unlike RingBuffer's 30-sample window, Summary should keep
+	// accounting for every insert, however many there are.
+	s := NewSummary()
+	for i := 0; i < 10000; i++ {
+		s.Insert(float64(i % 100))
+	}
+	if s.Count() != 10000 {
+		t.Errorf("expected count 10000, got %d", s.Count())
+	}
+}
@@ -0,0 +1,175 @@
+// Package fetcher scrapes Prometheus-compatible /metrics endpoints and
+// turns the exposition text format into MetricData samples that the
+// rest of hrmm can chart.
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// NullableFloat64 is a metric sample value. It is a plain float64 today;
+// the name leaves room for distinguishing "absent" (stale/NaN) samples
+// from zero without changing every call site later.
+type NullableFloat64 float64
+
+// MetricData is a single scraped Prometheus sample, along with the
+// HELP/TYPE metadata from its metric family.
+type MetricData struct {
+	Name     string
+	Help     string
+	Type     string
+	Labels   map[string]string
+	Value    NullableFloat64
+	Endpoint string // URL of the MetricsFetcher this sample was scraped from
+}
+
+// Identifier returns a stable key that distinguishes this sample from
+// others sharing the same metric name but different label sets.
+func (m MetricData) Identifier() string {
+	if len(m.Labels) == 0 {
+		return m.Name
+	}
+	keys := make([]string, 0, len(m.Labels))
+	for k := range m.Labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(m.Name)
+	b.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, "%s=%q", k, m.Labels[k])
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// Source identifies a metrics endpoint regardless of how it's reached:
+// MetricsFetcher polls one on demand, StreamFetcher maintains a
+// long-lived push connection to one. The dashboard uses URL as the key
+// for per-endpoint state (errors, connection status) common to both.
+type Source interface {
+	URL() string
+}
+
+// MetricsFetcher polls a single Prometheus-compatible endpoint.
+type MetricsFetcher struct {
+	url     string
+	metrics []string
+	labels  []string
+	client  *http.Client
+}
+
+// New creates a MetricsFetcher for the given endpoint URL. metrics, if
+// non-empty, restricts Fetch to those metric names. labels, if
+// non-empty, restricts returned samples to those carrying at least one
+// of the given label names.
+func New(url string, metrics []string, labels []string) *MetricsFetcher {
+	return NewWithClient(url, metrics, labels, http.DefaultClient)
+}
+
+// NewWithClient is like New but scrapes using the given *http.Client
+// instead of http.DefaultClient, e.g. one built by
+// HTTPClientConfig.ClientFor for an endpoint that requires TLS, basic
+// auth, or a bearer token.
+func NewWithClient(url string, metrics []string, labels []string, client *http.Client) *MetricsFetcher {
+	return &MetricsFetcher{
+		url:     url,
+		metrics: metrics,
+		labels:  labels,
+		client:  client,
+	}
+}
+
+// URL returns the endpoint this fetcher scrapes.
+func (f *MetricsFetcher) URL() string {
+	return f.url
+}
+
+// Fetch scrapes the endpoint and parses the Prometheus text exposition
+// format into MetricData samples, applying the metric/label filters
+// configured on New. It blocks with no deadline; callers that need to
+// bound how long a scrape can take (e.g. the dashboard's poll loop)
+// should use FetchContext instead.
+func (f *MetricsFetcher) Fetch() ([]MetricData, error) {
+	return f.FetchContext(context.Background())
+}
+
+// FetchContext is like Fetch but binds the scrape to ctx, so a slow or
+// hung endpoint can't block past ctx's deadline. A context error (e.g.
+// context.DeadlineExceeded) is returned wrapped, so callers should use
+// errors.Is rather than comparing it directly.
+func (f *MetricsFetcher) FetchContext(ctx context.Context) ([]MetricData, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("building request for %s: %w", f.url, err)
+	}
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s: %w", f.url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %s: unexpected status %s", f.url, resp.Status)
+	}
+
+	all, err := parse(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", f.url, err)
+	}
+
+	filtered := f.filter(all)
+	for i := range filtered {
+		filtered[i].Endpoint = f.url
+	}
+	return filtered, nil
+}
+
+func (f *MetricsFetcher) filter(all []MetricData) []MetricData {
+	return filterMetrics(all, f.metrics, f.labels)
+}
+
+// filterMetrics restricts all to the given metric names (if any) and to
+// samples carrying at least one of the given label names (if any).
+// Shared by MetricsFetcher and StreamFetcher, which apply the same
+// --metrics/--labels filters to two different transports.
+func filterMetrics(all []MetricData, metrics []string, labels []string) []MetricData {
+	if len(metrics) == 0 && len(labels) == 0 {
+		return all
+	}
+	wanted := make(map[string]bool, len(metrics))
+	for _, m := range metrics {
+		wanted[m] = true
+	}
+
+	var out []MetricData
+	for _, m := range all {
+		if len(wanted) > 0 && !wanted[m.Name] {
+			continue
+		}
+		if len(labels) > 0 && !hasAnyLabel(m.Labels, labels) {
+			continue
+		}
+		out = append(out, m)
+	}
+	return out
+}
+
+func hasAnyLabel(have map[string]string, want []string) bool {
+	for _, w := range want {
+		if _, ok := have[w]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,158 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestFetch_ParsesSimpleCounter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		w.Write([]byte(`# HELP test_counter A test counter
+# TYPE test_counter counter
+test_counter 42
+`))
+	}))
+	defer server.Close()
+
+	f := New(server.URL, nil, nil)
+	data, err := f.Fetch()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(data) != 1 {
+		t.Fatalf("expected 1 metric, got %d", len(data))
+	}
+	if data[0].Name != "test_counter" {
+		t.Errorf("expected name test_counter, got %s", data[0].Name)
+	}
+	if data[0].Help != "A test counter" {
+		t.Errorf("expected help text, got %q", data[0].Help)
+	}
+	if data[0].Type != "counter" {
+		t.Errorf("expected type counter, got %q", data[0].Type)
+	}
+	if float64(data[0].Value) != 42 {
+		t.Errorf("expected value 42, got %f", data[0].Value)
+	}
+}
+
+func TestFetch_ParsesLabels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`http_requests_total{method="GET",code="200"} 10
+http_requests_total{method="POST",code="500"} 3
+`))
+	}))
+	defer server.Close()
+
+	f := New(server.URL, nil, nil)
+	data, err := f.Fetch()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(data) != 2 {
+		t.Fatalf("expected 2 metrics, got %d", len(data))
+	}
+	if data[0].Labels["method"] != "GET" || data[0].Labels["code"] != "200" {
+		t.Errorf("unexpected labels: %v", data[0].Labels)
+	}
+	if data[0].Identifier() == data[1].Identifier() {
+		t.Error("expected distinct identifiers for distinct label sets")
+	}
+}
+
+func TestFetch_FiltersByMetricName(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("metric_a 1\nmetric_b 2\n"))
+	}))
+	defer server.Close()
+
+	f := New(server.URL, []string{"metric_b"}, nil)
+	data, err := f.Fetch()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(data) != 1 || data[0].Name != "metric_b" {
+		t.Fatalf("expected only metric_b, got %v", data)
+	}
+}
+
+func TestFetch_FiltersByLabel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`metric_a{env="prod"} 1
+metric_b 2
+`))
+	}))
+	defer server.Close()
+
+	f := New(server.URL, nil, []string{"env"})
+	data, err := f.Fetch()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(data) != 1 || data[0].Name != "metric_a" {
+		t.Fatalf("expected only metric_a, got %v", data)
+	}
+}
+
+func TestFetch_NonOKStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	f := New(server.URL, nil, nil)
+	if _, err := f.Fetch(); err == nil {
+		t.Error("expected error for non-200 status")
+	}
+}
+
+func TestFetchContext_TimesOutOnSlowEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.Write([]byte("metric_a 1\n"))
+	}))
+	defer server.Close()
+
+	f := New(server.URL, nil, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := f.FetchContext(ctx)
+	if err == nil {
+		t.Fatal("expected an error from the timed-out fetch")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected a wrapped context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestFetchContext_SucceedsWithinTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("metric_a 1\n"))
+	}))
+	defer server.Close()
+
+	f := New(server.URL, nil, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	data, err := f.FetchContext(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(data) != 1 {
+		t.Fatalf("expected 1 metric, got %d", len(data))
+	}
+}
+
+func TestMetricData_IdentifierNoLabels(t *testing.T) {
+	m := MetricData{Name: "simple_metric"}
+	if m.Identifier() != "simple_metric" {
+		t.Errorf("expected bare name, got %q", m.Identifier())
+	}
+}
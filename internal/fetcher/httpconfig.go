@@ -0,0 +1,200 @@
+package fetcher
+
+// httpconfig.go loads the standard Prometheus HTTP client config file
+// format (the same shape as promtool/Alertmanager's http_config) so
+// hrmm can scrape endpoints behind TLS, basic auth, or a bearer token.
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// BasicAuthConfig holds HTTP basic auth credentials.
+type BasicAuthConfig struct {
+	Username     string `yaml:"username"`
+	Password     string `yaml:"password"`
+	PasswordFile string `yaml:"password_file"`
+}
+
+// AuthorizationConfig holds a generic `Authorization: <Type> <Credentials>` header.
+type AuthorizationConfig struct {
+	Type            string `yaml:"type"`
+	Credentials     string `yaml:"credentials"`
+	CredentialsFile string `yaml:"credentials_file"`
+}
+
+// TLSConfig holds client TLS settings for scraping an HTTPS endpoint.
+type TLSConfig struct {
+	CAFile             string `yaml:"ca_file"`
+	CertFile           string `yaml:"cert_file"`
+	KeyFile            string `yaml:"key_file"`
+	ServerName         string `yaml:"server_name"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify"`
+}
+
+// HTTPClientConfig is the standard Prometheus HTTP client config file
+// shape: TLS, auth, and proxy settings to apply when scraping an
+// endpoint. URLOverrides lets a single file attach different
+// credentials to different endpoints.
+type HTTPClientConfig struct {
+	BasicAuth       *BasicAuthConfig            `yaml:"basic_auth"`
+	Authorization   *AuthorizationConfig        `yaml:"authorization"`
+	BearerToken     string                      `yaml:"bearer_token"`
+	BearerTokenFile string                      `yaml:"bearer_token_file"`
+	TLSConfig       *TLSConfig                  `yaml:"tls_config"`
+	ProxyURL        string                      `yaml:"proxy_url"`
+	URLOverrides    map[string]HTTPClientConfig `yaml:"url_overrides"`
+}
+
+// LoadHTTPClientConfig reads and parses an HTTP client config file.
+func LoadHTTPClientConfig(path string) (*HTTPClientConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading http config file %s: %w", path, err)
+	}
+	var cfg HTTPClientConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing http config file %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// ClientFor builds an *http.Client for the given endpoint URL, applying
+// cfg.URLOverrides[url] in place of the base config when present.
+func (cfg *HTTPClientConfig) ClientFor(endpoint string) (*http.Client, error) {
+	effective := *cfg
+	if override, ok := cfg.URLOverrides[endpoint]; ok {
+		effective = override
+	}
+	return effective.buildClient()
+}
+
+func (cfg HTTPClientConfig) buildClient() (*http.Client, error) {
+	tlsConfig, err := cfg.tlsClientConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	transport := &http.Transport{TLSClientConfig: tlsConfig}
+	if cfg.ProxyURL != "" {
+		proxyURL, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("parsing proxy_url: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	authHeader, err := cfg.authorizationHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Client{
+		Transport: &authRoundTripper{
+			base:       transport,
+			authHeader: authHeader,
+			basicAuth:  cfg.BasicAuth,
+		},
+	}, nil
+}
+
+func (cfg HTTPClientConfig) tlsClientConfig() (*tls.Config, error) {
+	if cfg.TLSConfig == nil {
+		return nil, nil
+	}
+	tc := cfg.TLSConfig
+
+	tlsConfig := &tls.Config{
+		ServerName:         tc.ServerName,
+		InsecureSkipVerify: tc.InsecureSkipVerify,
+	}
+
+	if tc.CAFile != "" {
+		caCert, err := os.ReadFile(tc.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading ca_file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("no certificates found in ca_file %s", tc.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if tc.CertFile != "" || tc.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(tc.CertFile, tc.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading cert_file/key_file: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// authorizationHeader resolves the bearer_token/bearer_token_file or
+// generic authorization config into a literal `Authorization` header
+// value, or "" if none is configured.
+func (cfg HTTPClientConfig) authorizationHeader() (string, error) {
+	switch {
+	case cfg.BearerToken != "":
+		return "Bearer " + cfg.BearerToken, nil
+	case cfg.BearerTokenFile != "":
+		token, err := os.ReadFile(cfg.BearerTokenFile)
+		if err != nil {
+			return "", fmt.Errorf("reading bearer_token_file: %w", err)
+		}
+		return "Bearer " + strings.TrimSpace(string(token)), nil
+	case cfg.Authorization != nil:
+		a := cfg.Authorization
+		creds := a.Credentials
+		if a.CredentialsFile != "" {
+			data, err := os.ReadFile(a.CredentialsFile)
+			if err != nil {
+				return "", fmt.Errorf("reading credentials_file: %w", err)
+			}
+			creds = strings.TrimSpace(string(data))
+		}
+		authType := a.Type
+		if authType == "" {
+			authType = "Bearer"
+		}
+		return authType + " " + creds, nil
+	}
+	return "", nil
+}
+
+// authRoundTripper attaches basic auth or an Authorization header to
+// every outgoing request before delegating to base.
+type authRoundTripper struct {
+	base       http.RoundTripper
+	authHeader string
+	basicAuth  *BasicAuthConfig
+}
+
+func (rt *authRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+
+	if rt.basicAuth != nil {
+		password := rt.basicAuth.Password
+		if rt.basicAuth.PasswordFile != "" {
+			data, err := os.ReadFile(rt.basicAuth.PasswordFile)
+			if err != nil {
+				return nil, fmt.Errorf("reading basic_auth password_file: %w", err)
+			}
+			password = strings.TrimSpace(string(data))
+		}
+		req.SetBasicAuth(rt.basicAuth.Username, password)
+	} else if rt.authHeader != "" {
+		req.Header.Set("Authorization", rt.authHeader)
+	}
+
+	return rt.base.RoundTrip(req)
+}
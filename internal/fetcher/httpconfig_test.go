@@ -0,0 +1,100 @@
+package fetcher
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadHTTPClientConfig_BearerToken(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "http.yaml")
+	if err := os.WriteFile(path, []byte("bearer_token: s3cr3t\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadHTTPClientConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.BearerToken != "s3cr3t" {
+		t.Errorf("expected bearer_token s3cr3t, got %q", cfg.BearerToken)
+	}
+}
+
+func TestHTTPClientConfig_BearerTokenAttachedToRequests(t *testing.T) {
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		w.Write([]byte("metric_a 1\n"))
+	}))
+	defer server.Close()
+
+	cfg := &HTTPClientConfig{BearerToken: "s3cr3t"}
+	client, err := cfg.ClientFor(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	f := NewWithClient(server.URL, nil, nil, client)
+	if _, err := f.Fetch(); err != nil {
+		t.Fatalf("unexpected fetch error: %v", err)
+	}
+	if gotAuth != "Bearer s3cr3t" {
+		t.Errorf("expected 'Bearer s3cr3t' authorization header, got %q", gotAuth)
+	}
+}
+
+func TestHTTPClientConfig_BasicAuthAttachedToRequests(t *testing.T) {
+	var gotUser, gotPass string
+	var gotOK bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUser, gotPass, gotOK = r.BasicAuth()
+		w.Write([]byte("metric_a 1\n"))
+	}))
+	defer server.Close()
+
+	cfg := &HTTPClientConfig{BasicAuth: &BasicAuthConfig{Username: "alice", Password: "hunter2"}}
+	client, err := cfg.ClientFor(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	f := NewWithClient(server.URL, nil, nil, client)
+	if _, err := f.Fetch(); err != nil {
+		t.Fatalf("unexpected fetch error: %v", err)
+	}
+	if !gotOK || gotUser != "alice" || gotPass != "hunter2" {
+		t.Errorf("expected basic auth alice/hunter2, got %q/%q (ok=%v)", gotUser, gotPass, gotOK)
+	}
+}
+
+func TestHTTPClientConfig_URLOverride(t *testing.T) {
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		w.Write([]byte("metric_a 1\n"))
+	}))
+	defer server.Close()
+
+	cfg := &HTTPClientConfig{
+		BearerToken: "default-token",
+		URLOverrides: map[string]HTTPClientConfig{
+			server.URL: {BearerToken: "override-token"},
+		},
+	}
+
+	client, err := cfg.ClientFor(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	f := NewWithClient(server.URL, nil, nil, client)
+	if _, err := f.Fetch(); err != nil {
+		t.Fatalf("unexpected fetch error: %v", err)
+	}
+	if gotAuth != "Bearer override-token" {
+		t.Errorf("expected override token, got %q", gotAuth)
+	}
+}
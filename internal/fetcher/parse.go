@@ -0,0 +1,138 @@
+package fetcher
+
+// parse.go implements a minimal reader for the Prometheus text exposition
+// format (https://prometheus.io/docs/instrumenting/exposition_formats/).
+// hrmm intentionally hand-rolls this instead of pulling in
+// client_golang/expfmt to keep the dependency footprint small.
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+)
+
+func parse(r io.Reader) ([]MetricData, error) {
+	scanner := bufio.NewScanner(r)
+	help := map[string]string{}
+	mtype := map[string]string{}
+	var result []MetricData
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "# HELP ") {
+			if name, text, ok := splitFirst(strings.TrimPrefix(line, "# HELP ")); ok {
+				help[name] = text
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "# TYPE ") {
+			if name, text, ok := splitFirst(strings.TrimPrefix(line, "# TYPE ")); ok {
+				mtype[name] = text
+			}
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, labels, valueStr, ok := splitSample(line)
+		if !ok {
+			continue
+		}
+		value, err := strconv.ParseFloat(valueStr, 64)
+		if err != nil {
+			continue
+		}
+
+		result = append(result, MetricData{
+			Name:   name,
+			Help:   help[name],
+			Type:   mtype[name],
+			Labels: labels,
+			Value:  NullableFloat64(value),
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// splitFirst splits "name rest of the line" on the first space.
+func splitFirst(s string) (name, rest string, ok bool) {
+	i := strings.IndexByte(s, ' ')
+	if i < 0 {
+		return "", "", false
+	}
+	return s[:i], s[i+1:], true
+}
+
+// splitSample parses a single exposition line of the form
+// `metric_name{label="value",...} 123.4` or `metric_name 123.4`.
+func splitSample(line string) (name string, labels map[string]string, value string, ok bool) {
+	braceStart := strings.IndexByte(line, '{')
+	if braceStart < 0 {
+		parts := strings.Fields(line)
+		if len(parts) < 2 {
+			return "", nil, "", false
+		}
+		return parts[0], nil, parts[1], true
+	}
+
+	braceEnd := strings.IndexByte(line[braceStart:], '}')
+	if braceEnd < 0 {
+		return "", nil, "", false
+	}
+	braceEnd += braceStart
+
+	name = line[:braceStart]
+	labels = parseLabels(line[braceStart+1 : braceEnd])
+	value = strings.TrimSpace(line[braceEnd+1:])
+	if value == "" {
+		return "", nil, "", false
+	}
+	return name, labels, value, true
+}
+
+func parseLabels(s string) map[string]string {
+	labels := map[string]string{}
+	for _, pair := range splitLabelPairs(s) {
+		eq := strings.IndexByte(pair, '=')
+		if eq < 0 {
+			continue
+		}
+		key := strings.TrimSpace(pair[:eq])
+		val := strings.Trim(strings.TrimSpace(pair[eq+1:]), `"`)
+		labels[key] = val
+	}
+	return labels
+}
+
+// splitLabelPairs splits a label list on commas, respecting quoted
+// values so a comma inside a label value isn't treated as a separator.
+func splitLabelPairs(s string) []string {
+	var pairs []string
+	var cur strings.Builder
+	inQuotes := false
+	for _, r := range s {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			cur.WriteRune(r)
+		case r == ',' && !inQuotes:
+			pairs = append(pairs, cur.String())
+			cur.Reset()
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if cur.Len() > 0 {
+		pairs = append(pairs, cur.String())
+	}
+	return pairs
+}
@@ -0,0 +1,164 @@
+package fetcher
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// StreamMsg is one value delivered on a StreamFetcher's channel: either
+// a batch of freshly-pushed samples (Data non-nil), or a connection
+// state transition for display (State non-empty, e.g. "connected" or
+// "reconnecting in 4s").
+type StreamMsg struct {
+	Data  []MetricData
+	State string
+}
+
+// StreamFetcher maintains a long-lived Server-Sent-Events connection to
+// a streaming metrics endpoint, instead of polling on demand like
+// MetricsFetcher. Each SSE event is expected to carry one
+// Prometheus-exposition-format snapshot in its data: field(s). It
+// implements Source.
+type StreamFetcher struct {
+	url     string
+	metrics []string
+	labels  []string
+	client  *http.Client
+}
+
+// NewStreamFetcher creates a StreamFetcher for the given SSE endpoint.
+// metrics and labels filter samples exactly as they do for
+// MetricsFetcher.
+func NewStreamFetcher(url string, metrics []string, labels []string) *StreamFetcher {
+	return NewStreamFetcherWithClient(url, metrics, labels, http.DefaultClient)
+}
+
+// NewStreamFetcherWithClient is like NewStreamFetcher but streams using
+// the given *http.Client instead of http.DefaultClient.
+func NewStreamFetcherWithClient(url string, metrics []string, labels []string, client *http.Client) *StreamFetcher {
+	return &StreamFetcher{url: url, metrics: metrics, labels: labels, client: client}
+}
+
+// URL returns the endpoint this fetcher streams from.
+func (f *StreamFetcher) URL() string {
+	return f.url
+}
+
+const (
+	streamBackoffMin = time.Second
+	streamBackoffMax = 30 * time.Second
+)
+
+// Connect opens an SSE connection to the endpoint and returns a channel
+// of StreamMsg values; the channel is closed once ctx is done. A
+// dropped or failed connection is retried automatically with
+// exponential backoff starting at 1s and capped at 30s, emitting a
+// "reconnecting in Ns" StreamMsg before each retry and "connected" once
+// a new connection succeeds.
+func (f *StreamFetcher) Connect(ctx context.Context) <-chan StreamMsg {
+	ch := make(chan StreamMsg)
+	go f.run(ctx, ch)
+	return ch
+}
+
+func (f *StreamFetcher) run(ctx context.Context, ch chan<- StreamMsg) {
+	defer close(ch)
+	backoff := streamBackoffMin
+	for ctx.Err() == nil {
+		connected := f.connectOnce(ctx, ch)
+		if ctx.Err() != nil {
+			return
+		}
+		if connected {
+			backoff = streamBackoffMin
+		}
+
+		select {
+		case ch <- StreamMsg{State: fmt.Sprintf("reconnecting in %s", backoff)}:
+		case <-ctx.Done():
+			return
+		}
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return
+		}
+
+		backoff *= 2
+		if backoff > streamBackoffMax {
+			backoff = streamBackoffMax
+		}
+	}
+}
+
+// connectOnce makes a single connection attempt and streams events
+// until the connection drops, the server closes it, or ctx is
+// cancelled. It reports whether the connection was established at all,
+// so run can reset its backoff after a connection that worked for a
+// while before dropping.
+func (f *StreamFetcher) connectOnce(ctx context.Context, ch chan<- StreamMsg) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.url, nil)
+	if err != nil {
+		return false
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false
+	}
+
+	select {
+	case ch <- StreamMsg{State: "connected"}:
+	case <-ctx.Done():
+		return true
+	}
+
+	var event strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "data:"):
+			if event.Len() > 0 {
+				event.WriteByte('\n')
+			}
+			event.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		case line == "":
+			if event.Len() == 0 {
+				continue
+			}
+			f.deliverEvent(ctx, ch, event.String())
+			event.Reset()
+		}
+	}
+	return true
+}
+
+// deliverEvent parses one SSE event's accumulated data as a Prometheus
+// exposition snapshot and sends the filtered samples on ch. A malformed
+// event is dropped silently rather than ending the connection over one
+// bad frame.
+func (f *StreamFetcher) deliverEvent(ctx context.Context, ch chan<- StreamMsg, data string) {
+	all, err := parse(strings.NewReader(data))
+	if err != nil {
+		return
+	}
+	filtered := filterMetrics(all, f.metrics, f.labels)
+	for i := range filtered {
+		filtered[i].Endpoint = f.url
+	}
+	select {
+	case ch <- StreamMsg{Data: filtered}:
+	case <-ctx.Done():
+	}
+}
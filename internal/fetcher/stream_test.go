@@ -0,0 +1,150 @@
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// writeSSEEvent writes one SSE event carrying a Prometheus exposition
+// snapshot in its data: field(s), then flushes it to the client.
+func writeSSEEvent(w http.ResponseWriter, body string) {
+	for _, line := range splitLines(body) {
+		fmt.Fprintf(w, "data: %s\n", line)
+	}
+	fmt.Fprint(w, "\n")
+	w.(http.Flusher).Flush()
+}
+
+func splitLines(s string) []string {
+	var lines []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\n' {
+			lines = append(lines, s[start:i])
+			start = i + 1
+		}
+	}
+	if start < len(s) {
+		lines = append(lines, s[start:])
+	}
+	return lines
+}
+
+func TestStreamFetcher_DeliversEventsAsTheyArrive(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		writeSSEEvent(w, "test_counter 1\n")
+		writeSSEEvent(w, "test_counter 2\n")
+		<-r.Context().Done() // keep the connection open until the client disconnects
+	}))
+	defer server.Close()
+
+	f := NewStreamFetcher(server.URL, nil, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := f.Connect(ctx)
+
+	var states []string
+	var values []float64
+	for len(values) < 2 {
+		select {
+		case msg := <-ch:
+			if msg.State != "" {
+				states = append(states, msg.State)
+			}
+			for _, d := range msg.Data {
+				values = append(values, float64(d.Value))
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("timed out waiting for streamed samples")
+		}
+	}
+
+	if len(states) == 0 || states[0] != "connected" {
+		t.Errorf("expected a \"connected\" state before any data, got %v", states)
+	}
+	if values[0] != 1 || values[1] != 2 {
+		t.Errorf("expected samples [1 2], got %v", values)
+	}
+}
+
+func TestStreamFetcher_ReconnectsAfterMidStreamClose(t *testing.T) {
+	var attempt int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		n := atomic.AddInt32(&attempt, 1)
+		if n == 1 {
+			writeSSEEvent(w, "test_counter 1\n")
+			return // first connection closes mid-session
+		}
+		writeSSEEvent(w, "test_counter 2\n")
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	f := NewStreamFetcher(server.URL, nil, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := f.Connect(ctx)
+
+	var sawReconnecting bool
+	var values []float64
+	deadline := time.After(35 * time.Second)
+	for len(values) < 2 {
+		select {
+		case msg := <-ch:
+			if msg.State != "" && msg.State != "connected" {
+				sawReconnecting = true
+			}
+			for _, d := range msg.Data {
+				values = append(values, float64(d.Value))
+			}
+		case <-deadline:
+			t.Fatal("timed out waiting for reconnect and second sample")
+		}
+	}
+
+	if !sawReconnecting {
+		t.Error("expected a \"reconnecting in ...\" state after the mid-stream close")
+	}
+	if values[0] != 1 || values[1] != 2 {
+		t.Errorf("expected samples [1 2] across both connections, got %v", values)
+	}
+}
+
+func TestStreamFetcher_ClosesChannelWhenContextDone(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	f := NewStreamFetcher(server.URL, nil, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch := f.Connect(ctx)
+
+	// Drain the "connected" state before cancelling.
+	select {
+	case <-ch:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for connection")
+	}
+	cancel()
+
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Error("expected the channel to be closed after ctx is cancelled")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for channel to close")
+	}
+}
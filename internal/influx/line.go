@@ -0,0 +1,34 @@
+// Package influx encodes samples as InfluxDB line protocol
+// (https://docs.influxdata.com/influxdb/v2/reference/syntax/line-protocol/).
+package influx
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var measurementEscaper = strings.NewReplacer(",", "\\,", " ", "\\ ")
+var tagEscaper = strings.NewReplacer(",", "\\,", "=", "\\=", " ", "\\ ")
+
+// EncodeLine renders a single sample as one line-protocol line:
+// measurement,tag1=v1,tag2=v2 value=<v> <unix-nanos>. tags are sorted
+// by key so output is deterministic.
+func EncodeLine(measurement string, tags map[string]string, value float64, ts time.Time) string {
+	var b strings.Builder
+	b.WriteString(measurementEscaper.Replace(measurement))
+
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(&b, ",%s=%s", tagEscaper.Replace(k), tagEscaper.Replace(tags[k]))
+	}
+
+	fmt.Fprintf(&b, " value=%s %d", strconv.FormatFloat(value, 'g', -1, 64), ts.UnixNano())
+	return b.String()
+}
@@ -0,0 +1,33 @@
+package influx
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEncodeLine_NoTags(t *testing.T) {
+	ts := time.Unix(1700000000, 0)
+	line := EncodeLine("cpu_usage", nil, 0.5, ts)
+	expected := "cpu_usage value=0.5 1700000000000000000"
+	if line != expected {
+		t.Errorf("expected %q, got %q", expected, line)
+	}
+}
+
+func TestEncodeLine_TagsSortedByKey(t *testing.T) {
+	ts := time.Unix(0, 0)
+	line := EncodeLine("http_requests_total", map[string]string{"method": "GET", "code": "200"}, 10, ts)
+	expected := "http_requests_total,code=200,method=GET value=10 0"
+	if line != expected {
+		t.Errorf("expected %q, got %q", expected, line)
+	}
+}
+
+func TestEncodeLine_EscapesSpecialCharacters(t *testing.T) {
+	ts := time.Unix(0, 0)
+	line := EncodeLine("my measurement", map[string]string{"a tag": "a, value"}, 1, ts)
+	expected := `my\ measurement,a\ tag=a\,\ value value=1 0`
+	if line != expected {
+		t.Errorf("expected %q, got %q", expected, line)
+	}
+}
@@ -0,0 +1,9 @@
+// Command hrmm polls Prometheus metrics endpoints and renders them for
+// humans, in a terminal graph or otherwise.
+package main
+
+import "github.com/mcpherrinm/hrmm/cmd"
+
+func main() {
+	cmd.Execute()
+}